@@ -0,0 +1,321 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// configSetting is a setting that can be read from the config file.
+type configSetting struct {
+	// key is the viper key the setting is read from
+	key string
+	// flag is the name of the flag bound to the key, if any
+	flag string
+	// secret settings are redacted when printed
+	secret bool
+}
+
+// configSettings lists every setting of the server config. In the config file a setting is
+// named by its key with underscores replaced by dashes, matching its environment variable
+// without the GITHUB_ prefix.
+var configSettings = []configSetting{
+	{key: "host", flag: "gh-host"},
+	{key: "rest-url", flag: "rest-url"},
+	{key: "graphql-url", flag: "graphql-url"},
+	{key: "upload-url", flag: "upload-url"},
+	{key: "raw-url", flag: "raw-url"},
+	{key: "personal_access_token", secret: true},
+	{key: "token-file", flag: "token-file"},
+	{key: "token-command", flag: "token-command"},
+	{key: "token-refresh-interval", flag: "token-refresh-interval"},
+	{key: "app-id", flag: "app-id"},
+	{key: "app-private-key-file", flag: "app-private-key-file"},
+	{key: "app-installation-id", flag: "app-installation-id"},
+	{key: "app-installation-owner", flag: "app-installation-owner"},
+	{key: "toolsets", flag: "toolsets"},
+	{key: "tools", flag: "tools"},
+	{key: "exclude-tools", flag: "exclude-tools"},
+	{key: "custom-toolsets"},
+	{key: "allow-repos", flag: "allow-repos"},
+	{key: "deny-repos", flag: "deny-repos"},
+	{key: "features", flag: "features"},
+	{key: "dynamic_toolsets", flag: "dynamic-toolsets"},
+	{key: "read-only", flag: "read-only"},
+	{key: "no-destructive", flag: "no-destructive"},
+	{key: "dry-run", flag: "dry-run"},
+	{key: "confirm-tools", flag: "confirm-tools"},
+	{key: "lockdown-mode", flag: "lockdown-mode"},
+	{key: "repo-access-cache-ttl", flag: "repo-access-cache-ttl"},
+	{key: "content-window-size", flag: "content-window-size"},
+	{key: "rate-limit-max-wait", flag: "rate-limit-max-wait"},
+	{key: "response-cache-size", flag: "response-cache-size"},
+	{key: "response-cache-dir", flag: "response-cache-dir"},
+	{key: "log-file", flag: "log-file"},
+	{key: "otlp-endpoint", flag: "otlp-endpoint"},
+	{key: "metrics-address", flag: "metrics-address"},
+	{key: "audit-log", flag: "audit-log"},
+	{key: "audit-log-max-size", flag: "audit-log-max-size"},
+	{key: "audit-log-max-backups", flag: "audit-log-max-backups"},
+	{key: "record-cassette", flag: "record-cassette"},
+	{key: "replay-cassette", flag: "replay-cassette"},
+	{key: "tool-timeout", flag: "tool-timeout"},
+	{key: "tool-timeouts", flag: "tool-timeouts"},
+	{key: "max-concurrent-tool-calls", flag: "max-concurrent-tool-calls"},
+	{key: "enable-command-logging", flag: "enable-command-logging"},
+	{key: "command-log-redact-keys", flag: "command-log-redact-keys"},
+	{key: "command-log-max-string-length", flag: "command-log-max-string-length"},
+	{key: "command-log-truncate-content", flag: "command-log-truncate-content"},
+	{key: "export-translations", flag: "export-translations"},
+	{key: "listen-address", flag: "listen-address"},
+	{key: "base-path", flag: "base-path"},
+	{key: "session-timeout", flag: "session-timeout"},
+	{key: "stateless", flag: "stateless"},
+	{key: "json-response", flag: "json-response"},
+	{key: "multi-tenant", flag: "multi-tenant"},
+}
+
+// configFileName is the file looked up in the user config directory when --config is not set.
+const configFileName = "config.yaml"
+
+// loadedConfig records where the config file values came from, for printing the effective config.
+var loadedConfig struct {
+	path       string
+	profile    string
+	baseKeys   map[string]bool
+	profileKey map[string]bool
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Print the effective configuration",
+	Long:  `Print the effective configuration after merging flags, environment variables, the config file and defaults, along with the source of each value.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return printEffectiveConfig(cmd)
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("config", "", "Path to a YAML or JSON config file (defaults to github-mcp-server/"+configFileName+" in the user config directory, if present)")
+	rootCmd.PersistentFlags().String("profile", "", "Name of the profile in the config file to apply on top of its top-level settings")
+
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+
+	rootCmd.AddCommand(configCmd)
+}
+
+// defaultConfigPath returns the config file in the user config directory, or "" if there is none.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	path := filepath.Join(dir, "github-mcp-server", configFileName)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// loadConfigFile merges the settings from the config file, and from the selected profile
+// within it, into viper. Config file values take precedence over defaults but not over
+// flags or environment variables.
+func loadConfigFile() error {
+	path := viper.GetString("config")
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	profile := viper.GetString("profile")
+	if path == "" {
+		if profile != "" {
+			return fmt.Errorf("profile %q selected but no config file found", profile)
+		}
+		return nil
+	}
+
+	fileViper := viper.New()
+	fileViper.SetConfigFile(path)
+	if err := fileViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	settings := fileViper.AllSettings()
+	profiles, err := configProfiles(settings["profiles"])
+	if err != nil {
+		return err
+	}
+	delete(settings, "profiles")
+
+	merged, err := configValues(settings)
+	if err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	loadedConfig.baseKeys = keySet(merged)
+
+	if profile != "" {
+		profileSettings, ok := profiles[profile]
+		if !ok {
+			return fmt.Errorf("profile %q not found in config file %s", profile, path)
+		}
+		profileValues, err := configValues(profileSettings)
+		if err != nil {
+			return fmt.Errorf("invalid profile %q in config file %s: %w", profile, path, err)
+		}
+		for key, value := range profileValues {
+			merged[key] = value
+		}
+		loadedConfig.profileKey = keySet(profileValues)
+	}
+
+	loadedConfig.path = path
+	loadedConfig.profile = profile
+	return viper.MergeConfigMap(merged)
+}
+
+// configProfiles returns the profiles section of the config file by name.
+func configProfiles(raw any) (map[string]map[string]any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	section, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("profiles in config file must be a map of profile names to settings")
+	}
+	profiles := make(map[string]map[string]any, len(section))
+	for name, value := range section {
+		settings, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("profile %q in config file must be a map of settings", name)
+		}
+		profiles[name] = settings
+	}
+	return profiles, nil
+}
+
+// configValues maps config file setting names to viper keys, rejecting unknown settings
+// so that typos do not silently fall back to defaults.
+func configValues(settings map[string]any) (map[string]any, error) {
+	values := make(map[string]any, len(settings))
+	for name, value := range settings {
+		setting, ok := lookupConfigSetting(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown setting %q", name)
+		}
+		values[setting.key] = value
+	}
+	return values, nil
+}
+
+func lookupConfigSetting(name string) (configSetting, bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	for _, setting := range configSettings {
+		if configFileKey(setting) == name {
+			return setting, true
+		}
+	}
+	return configSetting{}, false
+}
+
+// configFileKey is the name of a setting in the config file.
+func configFileKey(setting configSetting) string {
+	return strings.ReplaceAll(setting.key, "_", "-")
+}
+
+// envVarName is the environment variable a setting is read from.
+func envVarName(setting configSetting) string {
+	return "GITHUB_" + strings.ToUpper(strings.ReplaceAll(setting.key, "-", "_"))
+}
+
+func keySet(values map[string]any) map[string]bool {
+	keys := make(map[string]bool, len(values))
+	for key := range values {
+		keys[key] = true
+	}
+	return keys
+}
+
+// configSource reports where the effective value of a setting comes from.
+func configSource(cmd *cobra.Command, setting configSetting) string {
+	if setting.flag != "" {
+		if flag := lookupFlag(cmd, setting.flag); flag != nil && flag.Changed {
+			return "flag"
+		}
+	}
+	if _, ok := os.LookupEnv(envVarName(setting)); ok {
+		return "env"
+	}
+	if loadedConfig.profileKey[setting.key] {
+		return fmt.Sprintf("file (profile %s)", loadedConfig.profile)
+	}
+	if loadedConfig.baseKeys[setting.key] {
+		return "file"
+	}
+	return "default"
+}
+
+// lookupFlag finds a flag on the command being run, or on the command that defines it.
+func lookupFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	if flag := cmd.Flags().Lookup(name); flag != nil {
+		return flag
+	}
+	return httpCmd.Flags().Lookup(name)
+}
+
+func printEffectiveConfig(cmd *cobra.Command) error {
+	out := cmd.OutOrStdout()
+	if loadedConfig.path != "" {
+		_, _ = fmt.Fprintf(out, "Config file: %s\n", loadedConfig.path)
+		if loadedConfig.profile != "" {
+			_, _ = fmt.Fprintf(out, "Profile: %s\n", loadedConfig.profile)
+		}
+		_, _ = fmt.Fprintln(out)
+	}
+
+	settings := make([]configSetting, len(configSettings))
+	copy(settings, configSettings)
+	sort.Slice(settings, func(i, j int) bool { return configFileKey(settings[i]) < configFileKey(settings[j]) })
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SETTING\tVALUE\tSOURCE")
+	for _, setting := range settings {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", configFileKey(setting), formatConfigValue(setting), configSource(cmd, setting))
+	}
+	return w.Flush()
+}
+
+func formatConfigValue(setting configSetting) string {
+	value := viper.Get(setting.key)
+	if setting.secret {
+		if viper.GetString(setting.key) == "" {
+			return ""
+		}
+		return "********"
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case []any:
+		parts := make([]string, len(v))
+		for i, part := range v {
+			// Structured entries, such as custom toolsets, are listed by their id
+			if entry, ok := part.(map[string]any); ok && entry["id"] != nil {
+				part = entry["id"]
+			}
+			parts[i] = fmt.Sprint(part)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
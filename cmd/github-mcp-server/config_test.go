@@ -0,0 +1,267 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigFile = `
+toolsets: [repos]
+read-only: true
+content-window-size: 1000
+profiles:
+  work:
+    toolsets: [issues]
+    content-window-size: 2000
+`
+
+// newConfigTestCommand resets viper and the loaded config, and returns a command with a few
+// server flags bound to viper and parsed from args, as the root command does.
+func newConfigTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	reset := func() {
+		viper.Reset()
+		loadedConfig.path, loadedConfig.profile = "", ""
+		loadedConfig.baseKeys, loadedConfig.profileKey = nil, nil
+	}
+	reset()
+	t.Cleanup(reset)
+	// Keep a config file in the user config directory out of the tests
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	initConfig()
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("profile", "", "")
+	cmd.Flags().StringSlice("toolsets", nil, "")
+	cmd.Flags().Bool("read-only", false, "")
+	cmd.Flags().Int("content-window-size", 5000, "")
+	for _, name := range []string{"config", "profile", "toolsets", "read-only", "content-window-size"} {
+		require.NoError(t, viper.BindPFlag(name, cmd.Flags().Lookup(name)))
+	}
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfigFile_Precedence(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", testConfigFile)
+
+	tests := []struct {
+		name              string
+		args              []string
+		env               map[string]string
+		toolsets          []string
+		readOnly          bool
+		contentWindowSize int
+		// sources are the expected sources of toolsets, read-only and content-window-size
+		sources [3]string
+	}{
+		{
+			name:              "no config file",
+			toolsets:          []string{},
+			contentWindowSize: 5000,
+			sources:           [3]string{"default", "default", "default"},
+		},
+		{
+			name:              "config file",
+			args:              []string{"--config", path},
+			toolsets:          []string{"repos"},
+			readOnly:          true,
+			contentWindowSize: 1000,
+			sources:           [3]string{"file", "file", "file"},
+		},
+		{
+			name:              "profile over config file",
+			args:              []string{"--config", path, "--profile", "work"},
+			toolsets:          []string{"issues"},
+			readOnly:          true,
+			contentWindowSize: 2000,
+			sources:           [3]string{"file (profile work)", "file", "file (profile work)"},
+		},
+		{
+			name:              "environment over profile",
+			args:              []string{"--config", path, "--profile", "work"},
+			env:               map[string]string{"GITHUB_CONTENT_WINDOW_SIZE": "3000"},
+			toolsets:          []string{"issues"},
+			readOnly:          true,
+			contentWindowSize: 3000,
+			sources:           [3]string{"file (profile work)", "file", "env"},
+		},
+		{
+			name:              "flags over environment",
+			args:              []string{"--config", path, "--profile", "work", "--content-window-size", "4000", "--read-only=false"},
+			env:               map[string]string{"GITHUB_CONTENT_WINDOW_SIZE": "3000"},
+			toolsets:          []string{"issues"},
+			contentWindowSize: 4000,
+			sources:           [3]string{"file (profile work)", "flag", "flag"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			cmd := newConfigTestCommand(t, tc.args...)
+
+			require.NoError(t, loadConfigFile())
+
+			assert.Equal(t, tc.toolsets, viper.GetStringSlice("toolsets"))
+			assert.Equal(t, tc.readOnly, viper.GetBool("read-only"))
+			assert.Equal(t, tc.contentWindowSize, viper.GetInt("content-window-size"))
+			for i, key := range []string{"toolsets", "read-only", "content-window-size"} {
+				setting, ok := lookupConfigSetting(key)
+				require.True(t, ok)
+				assert.Equal(t, tc.sources[i], configSource(cmd, setting), key)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile_JSON(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"toolsets": ["actions"], "dynamic-toolsets": true, "profiles": {"ci": {"read_only": true}}}`)
+	newConfigTestCommand(t, "--config", path, "--profile", "ci")
+
+	require.NoError(t, loadConfigFile())
+	assert.Equal(t, []string{"actions"}, viper.GetStringSlice("toolsets"))
+	assert.True(t, viper.GetBool("dynamic_toolsets"))
+	assert.True(t, viper.GetBool("read-only"))
+}
+
+func TestLoadConfigFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		noFile  bool
+		err     string
+	}{
+		{
+			name:    "unknown setting",
+			content: "toolset: [repos]\n",
+			err:     `unknown setting "toolset"`,
+		},
+		{
+			name:    "unknown setting in profile",
+			content: "profiles:\n  work:\n    read-onyl: true\n",
+			profile: "work",
+			err:     `invalid profile "work"`,
+		},
+		{
+			name:    "profile not found",
+			content: testConfigFile,
+			profile: "home",
+			err:     `profile "home" not found`,
+		},
+		{
+			name:    "profiles not a map",
+			content: "profiles: [work]\n",
+			err:     "profiles in config file must be a map",
+		},
+		{
+			name:    "profile not a map",
+			content: "profiles:\n  work: true\n",
+			err:     `profile "work" in config file must be a map of settings`,
+		},
+		{
+			name:    "invalid file",
+			content: "toolsets: [repos\n",
+			err:     "failed to read config file",
+		},
+		{
+			name:    "missing file",
+			content: "",
+			noFile:  true,
+			err:     "failed to read config file",
+		},
+		{
+			name:    "profile without config file",
+			profile: "work",
+			err:     `profile "work" selected but no config file found`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args []string
+			switch {
+			case tc.noFile:
+				args = append(args, "--config", filepath.Join(t.TempDir(), "missing.yaml"))
+			case tc.content != "":
+				args = append(args, "--config", writeConfigFile(t, "config.yaml", tc.content))
+			}
+			if tc.profile != "" {
+				args = append(args, "--profile", tc.profile)
+			}
+			newConfigTestCommand(t, args...)
+
+			err := loadConfigFile()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestConfigValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		expected map[string]any
+		err      string
+	}{
+		{
+			name:     "names map to viper keys",
+			settings: map[string]any{"read-only": true, "dynamic-toolsets": true, "personal-access-token": "token"},
+			expected: map[string]any{"read-only": true, "dynamic_toolsets": true, "personal_access_token": "token"},
+		},
+		{
+			name:     "underscores and case are normalized",
+			settings: map[string]any{"Read_Only": true},
+			expected: map[string]any{"read-only": true},
+		},
+		{
+			name:     "flag names are not setting names",
+			settings: map[string]any{"gh-host": "ghe.example.com"},
+			err:      `unknown setting "gh-host"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := configValues(tc.settings)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestConfigSettingNames(t *testing.T) {
+	setting, ok := lookupConfigSetting("personal-access-token")
+	require.True(t, ok)
+	assert.Equal(t, "GITHUB_PERSONAL_ACCESS_TOKEN", envVarName(setting))
+	assert.Equal(t, "personal-access-token", configFileKey(setting))
+
+	setting, ok = lookupConfigSetting("host")
+	require.True(t, ok)
+	assert.Equal(t, "GITHUB_HOST", envVarName(setting))
+	assert.Equal(t, "gh-host", setting.flag)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the token, host and toolset configuration",
+	Long:  `Check the GitHub host, connectivity and token the server would run with, and list the tools it would enable along with the reason each other tool is hidden.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		stdioServerConfig, err := newStdioServerConfig()
+		if err != nil {
+			return err
+		}
+		// The report already explains what failed
+		cmd.SilenceUsage = true
+		return ghmcp.RunDoctor(cmd.Context(), stdioServerConfig, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
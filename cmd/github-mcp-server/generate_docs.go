@@ -50,8 +50,14 @@ func generateReadmeDocs(readmePath string) error {
 	// Create translation helper
 	t, _ := translations.TranslationHelper()
 
+	// Custom toolsets from the config file are documented alongside the built-in ones
+	customToolsets, err := newCustomToolsets()
+	if err != nil {
+		return err
+	}
+
 	// (not available to regular users) while including tools with FeatureFlagDisable.
-	r := github.NewInventory(t).WithToolsets([]string{"all"}).Build()
+	r := github.NewInventory(t).WithToolsets([]string{"all"}).WithCustomToolsets(customToolsets).Build()
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(r)
@@ -149,6 +155,16 @@ func generateToolsetsDoc(i *inventory.Inventory) string {
 		fmt.Fprintf(&buf, "| %s | `%s` | %s |\n", icon, ts.ID, ts.Description)
 	}
 
+	// Custom toolsets list their member tools, which are documented under their own toolsets
+	for _, ts := range i.CustomToolsets() {
+		icon := octiconImg(ts.Icon)
+		members := make([]string, len(ts.Tools))
+		for j, name := range ts.Tools {
+			members[j] = "`" + name + "`"
+		}
+		fmt.Fprintf(&buf, "| %s | `%s` | %s<br>Custom toolset: %s |\n", icon, ts.ID, ts.Description, strings.Join(members, ", "))
+	}
+
 	return strings.TrimSuffix(buf.String(), "\n")
 }
 
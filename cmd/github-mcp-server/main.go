@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -8,7 +9,14 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/confirm"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/limits"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/repopolicy"
+	"github.com/github/github-mcp-server/pkg/tokensource"
+	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -25,6 +33,9 @@ var (
 		Short:   "GitHub MCP Server",
 		Long:    `A GitHub MCP server that handles various tools and resources.`,
 		Version: fmt.Sprintf("Version: %s\nCommit: %s\nBuild Date: %s", version, commit, date),
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return loadConfigFile()
+		},
 	}
 
 	stdioCmd = &cobra.Command{
@@ -32,64 +43,289 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
+			stdioServerConfig, err := newStdioServerConfig()
+			if err != nil {
+				return err
+			}
+			if !hasCredentials(stdioServerConfig) {
 				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
 			}
+			return ghmcp.RunStdioServer(stdioServerConfig)
+		},
+	}
 
-			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
-			// it's because viper doesn't handle comma-separated values correctly for env
-			// vars when using GetStringSlice.
-			// https://github.com/spf13/viper/issues/380
-			//
-			// Additionally, viper.UnmarshalKey returns an empty slice even when the flag
-			// is not set, but we need nil to indicate "use defaults". So we check IsSet first.
-			var enabledToolsets []string
-			if viper.IsSet("toolsets") {
-				if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-					return fmt.Errorf("failed to unmarshal toolsets: %w", err)
-				}
+	httpCmd = &cobra.Command{
+		Use:   "http",
+		Short: "Start Streamable HTTP server",
+		Long:  `Start a server that communicates via the MCP Streamable HTTP transport, allowing multiple clients to share one long-lived server.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			stdioServerConfig, err := newStdioServerConfig()
+			if err != nil {
+				return err
 			}
-			// else: enabledToolsets stays nil, meaning "use defaults"
-
-			// Parse tools (similar to toolsets)
-			var enabledTools []string
-			if viper.IsSet("tools") {
-				if err := viper.UnmarshalKey("tools", &enabledTools); err != nil {
-					return fmt.Errorf("failed to unmarshal tools: %w", err)
-				}
+			multiTenant := viper.GetBool("multi-tenant")
+			if !hasCredentials(stdioServerConfig) && !multiTenant {
+				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set (or use --multi-tenant to take tokens from requests)")
 			}
-
-			// Parse enabled features (similar to toolsets)
-			var enabledFeatures []string
-			if viper.IsSet("features") {
-				if err := viper.UnmarshalKey("features", &enabledFeatures); err != nil {
-					return fmt.Errorf("failed to unmarshal features: %w", err)
-				}
+			httpServerConfig := ghmcp.HTTPServerConfig{
+				StdioServerConfig: stdioServerConfig,
+				ListenAddress:     viper.GetString("listen-address"),
+				BasePath:          viper.GetString("base-path"),
+				SessionTimeout:    viper.GetDuration("session-timeout"),
+				Stateless:         viper.GetBool("stateless"),
+				JSONResponse:      viper.GetBool("json-response"),
+				MultiTenant:       multiTenant,
 			}
-
-			ttl := viper.GetDuration("repo-access-cache-ttl")
-			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				EnabledTools:         enabledTools,
-				EnabledFeatures:      enabledFeatures,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
-				LockdownMode:         viper.GetBool("lockdown-mode"),
-				RepoAccessCacheTTL:   &ttl,
-			}
-			return ghmcp.RunStdioServer(stdioServerConfig)
+			return ghmcp.RunHTTPServer(httpServerConfig)
 		},
 	}
 )
 
+// newStdioServerConfig builds the server configuration shared by all transports from flags and env vars.
+// Commands decide for themselves whether a token is required.
+func newStdioServerConfig() (ghmcp.StdioServerConfig, error) {
+	// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
+	// it's because viper doesn't handle comma-separated values correctly for env
+	// vars when using GetStringSlice.
+	// https://github.com/spf13/viper/issues/380
+	//
+	// Additionally, viper.UnmarshalKey returns an empty slice even when the flag
+	// is not set, but we need nil to indicate "use defaults". So we check IsSet first.
+	var enabledToolsets []string
+	if viper.IsSet("toolsets") {
+		if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal toolsets: %w", err)
+		}
+	}
+	// else: enabledToolsets stays nil, meaning "use defaults"
+
+	// Parse tools (similar to toolsets)
+	var enabledTools []string
+	if viper.IsSet("tools") {
+		if err := viper.UnmarshalKey("tools", &enabledTools); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal tools: %w", err)
+		}
+	}
+
+	var excludeTools []string
+	if viper.IsSet("exclude-tools") {
+		if err := viper.UnmarshalKey("exclude-tools", &excludeTools); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal exclude tools: %w", err)
+		}
+	}
+
+	customToolsets, err := newCustomToolsets()
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	// Parse enabled features (similar to toolsets)
+	var enabledFeatures []string
+	if viper.IsSet("features") {
+		if err := viper.UnmarshalKey("features", &enabledFeatures); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal features: %w", err)
+		}
+	}
+
+	var commandLogRedactKeys []string
+	if viper.IsSet("command-log-redact-keys") {
+		if err := viper.UnmarshalKey("command-log-redact-keys", &commandLogRedactKeys); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal command log redact keys: %w", err)
+		}
+	}
+	commandLogRedaction := mcplog.Redaction{
+		Keys:            commandLogRedactKeys,
+		MaxStringLength: viper.GetInt("command-log-max-string-length"),
+		TruncateContent: viper.GetBool("command-log-truncate-content"),
+	}
+
+	var toolTimeoutValues []string
+	if viper.IsSet("tool-timeouts") {
+		if err := viper.UnmarshalKey("tool-timeouts", &toolTimeoutValues); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal tool timeouts: %w", err)
+		}
+	}
+	toolTimeouts, err := limits.ParseToolTimeouts(toolTimeoutValues)
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+	toolLimits := limits.Config{
+		Timeout:       viper.GetDuration("tool-timeout"),
+		ToolTimeouts:  toolTimeouts,
+		MaxConcurrent: viper.GetInt("max-concurrent-tool-calls"),
+	}
+	if err := toolLimits.Validate(github.AllTools(translations.NullTranslationHelper)); err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	var allowRepos, denyRepos []string
+	if viper.IsSet("allow-repos") {
+		if err := viper.UnmarshalKey("allow-repos", &allowRepos); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal allowed repositories: %w", err)
+		}
+	}
+	if viper.IsSet("deny-repos") {
+		if err := viper.UnmarshalKey("deny-repos", &denyRepos); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal denied repositories: %w", err)
+		}
+	}
+	repoPolicy, err := repopolicy.Parse(allowRepos, denyRepos)
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	var confirmEntries []string
+	if viper.IsSet("confirm-tools") {
+		if err := viper.UnmarshalKey("confirm-tools", &confirmEntries); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal tools to confirm: %w", err)
+		}
+	}
+	confirmTools, err := confirm.Parse(confirmEntries)
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+	if err := confirmTools.Validate(github.AllTools(translations.NullTranslationHelper)); err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	var githubApp *ghmcp.GitHubAppConfig
+	if appID := viper.GetInt64("app-id"); appID != 0 {
+		githubApp = &ghmcp.GitHubAppConfig{
+			AppID:             appID,
+			PrivateKeyPath:    viper.GetString("app-private-key-file"),
+			InstallationID:    viper.GetInt64("app-installation-id"),
+			InstallationOwner: viper.GetString("app-installation-owner"),
+		}
+		if githubApp.PrivateKeyPath == "" {
+			return ghmcp.StdioServerConfig{}, errors.New("--app-private-key-file is required when --app-id is set")
+		}
+		if githubApp.InstallationID == 0 && githubApp.InstallationOwner == "" {
+			return ghmcp.StdioServerConfig{}, errors.New("--app-installation-id or --app-installation-owner is required when --app-id is set")
+		}
+	}
+
+	tokenSource, err := newTokenSource()
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	ttl := viper.GetDuration("repo-access-cache-ttl")
+	return ghmcp.StdioServerConfig{
+		Version: version,
+		Host:    viper.GetString("host"),
+		EndpointOverrides: ghmcp.EndpointOverrides{
+			RESTURL:    viper.GetString("rest-url"),
+			GraphQLURL: viper.GetString("graphql-url"),
+			UploadURL:  viper.GetString("upload-url"),
+			RawURL:     viper.GetString("raw-url"),
+		},
+		Token:                viper.GetString("personal_access_token"),
+		TokenSource:          tokenSource,
+		GitHubApp:            githubApp,
+		EnabledToolsets:      enabledToolsets,
+		EnabledTools:         enabledTools,
+		ExcludeTools:         excludeTools,
+		CustomToolsets:       customToolsets,
+		EnabledFeatures:      enabledFeatures,
+		DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
+		ReadOnly:             viper.GetBool("read-only"),
+		NoDestructive:        viper.GetBool("no-destructive"),
+		DryRun:               viper.GetBool("dry-run"),
+		ConfirmTools:         confirmTools,
+		ExportTranslations:   viper.GetBool("export-translations"),
+		EnableCommandLogging: viper.GetBool("enable-command-logging"),
+		CommandLogRedaction:  commandLogRedaction,
+		LogFilePath:          viper.GetString("log-file"),
+		ContentWindowSize:    viper.GetInt("content-window-size"),
+		LockdownMode:         viper.GetBool("lockdown-mode"),
+		RepoAccessCacheTTL:   &ttl,
+		RateLimitMaxWait:     viper.GetDuration("rate-limit-max-wait"),
+		ResponseCacheSize:    viper.GetInt64("response-cache-size") << 20,
+		ResponseCacheDir:     viper.GetString("response-cache-dir"),
+		OTLPEndpoint:         viper.GetString("otlp-endpoint"),
+		MetricsAddress:       viper.GetString("metrics-address"),
+		AuditLogPath:         viper.GetString("audit-log"),
+		AuditLogMaxSize:      viper.GetInt64("audit-log-max-size") << 20,
+		AuditLogMaxBackups:   viper.GetInt("audit-log-max-backups"),
+		RecordCassette:       viper.GetString("record-cassette"),
+		ReplayCassette:       viper.GetString("replay-cassette"),
+		ToolLimits:           toolLimits,
+		RepoPolicy:           repoPolicy,
+	}, nil
+}
+
+// newTokenSource returns the token source configured by --token-file or --token-command,
+// or nil when the token is taken from GITHUB_PERSONAL_ACCESS_TOKEN.
+func newTokenSource() (tokensource.TokenSource, error) {
+	tokenFile := viper.GetString("token-file")
+	tokenCommand := viper.GetString("token-command")
+	interval := viper.GetDuration("token-refresh-interval")
+
+	switch {
+	case tokenFile != "" && tokenCommand != "":
+		return nil, errors.New("--token-file and --token-command cannot be used together")
+	case tokenFile != "":
+		return tokensource.File(tokenFile, interval), nil
+	case tokenCommand != "":
+		// The command is split on whitespace and run directly, not through a shell
+		parts := strings.Fields(tokenCommand)
+		if len(parts) == 0 {
+			return nil, errors.New("--token-command must name a command")
+		}
+		return tokensource.Command(parts[0], parts[1:], interval), nil
+	default:
+		return nil, nil
+	}
+}
+
+// customToolsetConfig is a custom toolset as declared in the config file.
+type customToolsetConfig struct {
+	ID          string   `mapstructure:"id" json:"id"`
+	Description string   `mapstructure:"description" json:"description"`
+	Icon        string   `mapstructure:"icon" json:"icon"`
+	Default     bool     `mapstructure:"default" json:"default"`
+	Tools       []string `mapstructure:"tools" json:"tools"`
+}
+
+// newCustomToolsets returns the custom toolsets declared by the custom-toolsets setting: a
+// list in the config file, or a JSON array in GITHUB_CUSTOM_TOOLSETS.
+func newCustomToolsets() ([]inventory.CustomToolset, error) {
+	if !viper.IsSet("custom-toolsets") {
+		return nil, nil
+	}
+	var configs []customToolsetConfig
+	if raw, ok := viper.Get("custom-toolsets").(string); ok {
+		if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+			return nil, fmt.Errorf("failed to parse custom toolsets as JSON: %w", err)
+		}
+	} else if err := viper.UnmarshalKey("custom-toolsets", &configs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custom toolsets: %w", err)
+	}
+
+	toolsets := make([]inventory.CustomToolset, 0, len(configs))
+	for _, c := range configs {
+		toolsets = append(toolsets, inventory.CustomToolset{
+			ToolsetMetadata: inventory.ToolsetMetadata{
+				ID:          inventory.ToolsetID(strings.TrimSpace(c.ID)),
+				Description: c.Description,
+				Icon:        c.Icon,
+				Default:     c.Default,
+			},
+			Tools: github.CleanTools(c.Tools),
+		})
+	}
+	if err := github.ValidateCustomToolsets(toolsets); err != nil {
+		return nil, err
+	}
+	return toolsets, nil
+}
+
+// hasCredentials reports whether the config can authenticate with the GitHub API on its own.
+// Replaying a cassette never reaches the GitHub API, so it needs no credentials.
+func hasCredentials(cfg ghmcp.StdioServerConfig) bool {
+	return cfg.Token != "" || cfg.TokenSource != nil || cfg.GitHubApp != nil || cfg.ReplayCassette != ""
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.SetGlobalNormalizationFunc(wordSepNormalizeFunc)
@@ -98,34 +334,116 @@ func init() {
 
 	// Add global flags that will be shared by all commands
 	rootCmd.PersistentFlags().StringSlice("toolsets", nil, github.GenerateToolsetsHelp())
-	rootCmd.PersistentFlags().StringSlice("tools", nil, "Comma-separated list of specific tools to enable")
+	rootCmd.PersistentFlags().StringSlice("tools", nil, "Comma-separated list of specific tools to enable, or glob patterns such as get_*")
+	rootCmd.PersistentFlags().StringSlice("exclude-tools", nil, "Comma-separated list of tools, or glob patterns such as *delete*, to leave out even if their toolset is enabled")
+	rootCmd.PersistentFlags().StringSlice("allow-repos", nil, "Comma-separated owner/repo patterns, such as my-org/*, that tools may act on; prefix with read: or write: to limit one kind of access")
+	rootCmd.PersistentFlags().StringSlice("deny-repos", nil, "Comma-separated owner/repo patterns that tools may not act on; prefix with read: or write: to deny one kind of access")
 	rootCmd.PersistentFlags().StringSlice("features", nil, "Comma-separated list of feature flags to enable")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("no-destructive", false, "Hide tools marked destructive, such as delete_file, while keeping other write tools")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Return the GitHub API requests write tools would send instead of sending them")
+	rootCmd.PersistentFlags().StringSlice("confirm-tools", nil, "Comma-separated tools, glob patterns or tool:method entries whose calls the user must confirm; destructive confirms every tool marked destructive")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
+	rootCmd.PersistentFlags().StringSlice("command-log-redact-keys", nil, "Comma-separated list of additional field names whose values are redacted from command logs")
+	rootCmd.PersistentFlags().Int("command-log-max-string-length", mcplog.DefaultMaxStringLength, "Longest string value logged in full by command logging (0 for no limit)")
+	rootCmd.PersistentFlags().Bool("command-log-truncate-content", false, "Log only the length of file and resource contents in command logs")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().Duration("rate-limit-max-wait", 0, "Longest a GitHub API request may wait for rate limits to clear before failing (0s to fail immediately)")
+	rootCmd.PersistentFlags().Int64("response-cache-size", 0, "Megabytes of GitHub API responses to cache and revalidate with conditional requests (0 disables the cache)")
+	rootCmd.PersistentFlags().String("response-cache-dir", "", "Keep the response cache in this directory, so it survives restarts, instead of in memory (requires --response-cache-size)")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "Export OpenTelemetry traces of tool calls and GitHub API requests to this OTLP/HTTP endpoint (e.g. http://localhost:4318)")
+	rootCmd.PersistentFlags().String("metrics-address", "", "Serve Prometheus metrics at /metrics on this address (e.g. 127.0.0.1:9090)")
+	rootCmd.PersistentFlags().String("audit-log", "", "Record every call of a write tool as a JSON line in this file")
+	rootCmd.PersistentFlags().Int64("audit-log-max-size", 100, "Size in megabytes at which the audit log is rotated (0 disables rotation)")
+	rootCmd.PersistentFlags().Int("audit-log-max-backups", 5, "Number of rotated audit log files to keep")
+	rootCmd.PersistentFlags().String("record-cassette", "", "Record GitHub API requests and responses to this cassette file, without credentials")
+	rootCmd.PersistentFlags().String("replay-cassette", "", "Serve GitHub API responses from this cassette file instead of GitHub")
+	rootCmd.PersistentFlags().Duration("tool-timeout", 0, "Fail tool calls that run longer than this (0s for no timeout)")
+	rootCmd.PersistentFlags().StringSlice("tool-timeouts", nil, "Comma-separated per-tool timeouts overriding --tool-timeout, as tool=duration (e.g. search_code=2m)")
+	rootCmd.PersistentFlags().Int("max-concurrent-tool-calls", 0, "Most tool calls to run at once across all sessions (0 for no limit)")
+	rootCmd.PersistentFlags().String("rest-url", "", "Override the REST API base URL derived from --gh-host (e.g. to use a proxy)")
+	rootCmd.PersistentFlags().String("graphql-url", "", "Override the GraphQL API URL derived from --gh-host")
+	rootCmd.PersistentFlags().String("upload-url", "", "Override the uploads URL derived from --gh-host")
+	rootCmd.PersistentFlags().String("raw-url", "", "Override the raw content URL derived from --gh-host")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
 	rootCmd.PersistentFlags().Bool("lockdown-mode", false, "Enable lockdown mode")
 	rootCmd.PersistentFlags().Duration("repo-access-cache-ttl", 5*time.Minute, "Override the repo access cache TTL (e.g. 1m, 0s to disable)")
+	rootCmd.PersistentFlags().String("token-file", "", "Read the GitHub token from this file instead of GITHUB_PERSONAL_ACCESS_TOKEN")
+	rootCmd.PersistentFlags().String("token-command", "", "Run this command (e.g. \"gh auth token\") and use its output as the GitHub token")
+	rootCmd.PersistentFlags().Duration("token-refresh-interval", 0, "Re-read the token from --token-file or --token-command this often (0s to only re-read after the token is rejected)")
+	rootCmd.PersistentFlags().Int64("app-id", 0, "Authenticate as an installation of the GitHub App with this ID instead of with a personal access token")
+	rootCmd.PersistentFlags().String("app-private-key-file", "", "Path to the PEM encoded private key of the GitHub App")
+	rootCmd.PersistentFlags().Int64("app-installation-id", 0, "ID of the GitHub App installation to authenticate as")
+	rootCmd.PersistentFlags().String("app-installation-owner", "", "Organization or user the GitHub App is installed on, used to find the installation when no ID is given")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("tools", rootCmd.PersistentFlags().Lookup("tools"))
+	_ = viper.BindPFlag("exclude-tools", rootCmd.PersistentFlags().Lookup("exclude-tools"))
+	_ = viper.BindPFlag("allow-repos", rootCmd.PersistentFlags().Lookup("allow-repos"))
+	_ = viper.BindPFlag("deny-repos", rootCmd.PersistentFlags().Lookup("deny-repos"))
 	_ = viper.BindPFlag("features", rootCmd.PersistentFlags().Lookup("features"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("no-destructive", rootCmd.PersistentFlags().Lookup("no-destructive"))
+	_ = viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	_ = viper.BindPFlag("confirm-tools", rootCmd.PersistentFlags().Lookup("confirm-tools"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
+	_ = viper.BindPFlag("command-log-redact-keys", rootCmd.PersistentFlags().Lookup("command-log-redact-keys"))
+	_ = viper.BindPFlag("command-log-max-string-length", rootCmd.PersistentFlags().Lookup("command-log-max-string-length"))
+	_ = viper.BindPFlag("command-log-truncate-content", rootCmd.PersistentFlags().Lookup("command-log-truncate-content"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("rate-limit-max-wait", rootCmd.PersistentFlags().Lookup("rate-limit-max-wait"))
+	_ = viper.BindPFlag("response-cache-size", rootCmd.PersistentFlags().Lookup("response-cache-size"))
+	_ = viper.BindPFlag("response-cache-dir", rootCmd.PersistentFlags().Lookup("response-cache-dir"))
+	_ = viper.BindPFlag("otlp-endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
+	_ = viper.BindPFlag("metrics-address", rootCmd.PersistentFlags().Lookup("metrics-address"))
+	_ = viper.BindPFlag("audit-log", rootCmd.PersistentFlags().Lookup("audit-log"))
+	_ = viper.BindPFlag("audit-log-max-size", rootCmd.PersistentFlags().Lookup("audit-log-max-size"))
+	_ = viper.BindPFlag("audit-log-max-backups", rootCmd.PersistentFlags().Lookup("audit-log-max-backups"))
+	_ = viper.BindPFlag("record-cassette", rootCmd.PersistentFlags().Lookup("record-cassette"))
+	_ = viper.BindPFlag("replay-cassette", rootCmd.PersistentFlags().Lookup("replay-cassette"))
+	_ = viper.BindPFlag("tool-timeout", rootCmd.PersistentFlags().Lookup("tool-timeout"))
+	_ = viper.BindPFlag("tool-timeouts", rootCmd.PersistentFlags().Lookup("tool-timeouts"))
+	_ = viper.BindPFlag("max-concurrent-tool-calls", rootCmd.PersistentFlags().Lookup("max-concurrent-tool-calls"))
+	_ = viper.BindPFlag("rest-url", rootCmd.PersistentFlags().Lookup("rest-url"))
+	_ = viper.BindPFlag("graphql-url", rootCmd.PersistentFlags().Lookup("graphql-url"))
+	_ = viper.BindPFlag("upload-url", rootCmd.PersistentFlags().Lookup("upload-url"))
+	_ = viper.BindPFlag("raw-url", rootCmd.PersistentFlags().Lookup("raw-url"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
 	_ = viper.BindPFlag("lockdown-mode", rootCmd.PersistentFlags().Lookup("lockdown-mode"))
 	_ = viper.BindPFlag("repo-access-cache-ttl", rootCmd.PersistentFlags().Lookup("repo-access-cache-ttl"))
+	_ = viper.BindPFlag("token-file", rootCmd.PersistentFlags().Lookup("token-file"))
+	_ = viper.BindPFlag("token-command", rootCmd.PersistentFlags().Lookup("token-command"))
+	_ = viper.BindPFlag("token-refresh-interval", rootCmd.PersistentFlags().Lookup("token-refresh-interval"))
+	_ = viper.BindPFlag("app-id", rootCmd.PersistentFlags().Lookup("app-id"))
+	_ = viper.BindPFlag("app-private-key-file", rootCmd.PersistentFlags().Lookup("app-private-key-file"))
+	_ = viper.BindPFlag("app-installation-id", rootCmd.PersistentFlags().Lookup("app-installation-id"))
+	_ = viper.BindPFlag("app-installation-owner", rootCmd.PersistentFlags().Lookup("app-installation-owner"))
+
+	// Add HTTP transport flags
+	httpCmd.Flags().String("listen-address", ghmcp.DefaultHTTPListenAddress, "Address to listen on for HTTP connections")
+	httpCmd.Flags().String("base-path", ghmcp.DefaultHTTPBasePath, "URL path to serve the MCP endpoint on")
+	httpCmd.Flags().Duration("session-timeout", 30*time.Minute, "Close sessions idle for longer than this duration (0s to keep sessions open)")
+	httpCmd.Flags().Bool("stateless", false, "Do not track sessions; every request is handled independently")
+	httpCmd.Flags().Bool("json-response", false, "Respond with application/json instead of streaming text/event-stream responses")
+	httpCmd.Flags().Bool("multi-tenant", false, "Authenticate each request with the GitHub token in its Authorization header instead of GITHUB_PERSONAL_ACCESS_TOKEN")
+
+	_ = viper.BindPFlag("listen-address", httpCmd.Flags().Lookup("listen-address"))
+	_ = viper.BindPFlag("base-path", httpCmd.Flags().Lookup("base-path"))
+	_ = viper.BindPFlag("session-timeout", httpCmd.Flags().Lookup("session-timeout"))
+	_ = viper.BindPFlag("stateless", httpCmd.Flags().Lookup("stateless"))
+	_ = viper.BindPFlag("json-response", httpCmd.Flags().Lookup("json-response"))
+	_ = viper.BindPFlag("multi-tenant", httpCmd.Flags().Lookup("multi-tenant"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
+	rootCmd.AddCommand(httpCmd)
 }
 
 func initConfig() {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/spf13/cobra"
+)
+
+var (
+	toolsCmd = &cobra.Command{
+		Use:   "tools",
+		Short: "Inspect the tools the server would enable",
+	}
+
+	toolsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the tools the server would enable",
+		Long: `List the tools the server would enable with the same flags, environment and config file. With --explain every tool is listed with the filter stage that decided whether it is enabled and the trace of stages evaluated for it.
+
+Tools are not filtered by token scopes, as that needs the token to be checked; run doctor for that.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			explain, _ := cmd.Flags().GetBool("explain")
+			if format != "markdown" && format != "json" {
+				return fmt.Errorf("unsupported format %q: expected markdown or json", format)
+			}
+			stdioServerConfig, err := newStdioServerConfig()
+			if err != nil {
+				return err
+			}
+
+			inv := ghmcp.ToolInventory(stdioServerConfig)
+			if unrecognized := inv.UnrecognizedToolsets(); len(unrecognized) > 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: unrecognized toolsets ignored: %s\n", strings.Join(unrecognized, ", "))
+			}
+			if unrecognized := inv.UnrecognizedTools(); len(unrecognized) > 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: unrecognized tools ignored: %s\n", strings.Join(unrecognized, ", "))
+			}
+			decisions := inv.ExplainTools(cmd.Context())
+			if !explain {
+				decisions = enabledDecisions(decisions)
+			}
+			if format == "json" {
+				return writeToolsJSON(cmd.OutOrStdout(), decisions, explain)
+			}
+			writeToolsMarkdown(cmd.OutOrStdout(), decisions, explain)
+			return nil
+		},
+	}
+)
+
+func init() {
+	toolsListCmd.Flags().Bool("explain", false, "List every tool with the reason it is enabled or hidden")
+	toolsListCmd.Flags().String("format", "markdown", "Output format: markdown or json")
+
+	toolsCmd.AddCommand(toolsListCmd)
+	rootCmd.AddCommand(toolsCmd)
+}
+
+// enabledDecisions returns the decisions for enabled tools.
+func enabledDecisions(decisions []inventory.ToolDecision) []inventory.ToolDecision {
+	var result []inventory.ToolDecision
+	for _, decision := range decisions {
+		if decision.Enabled {
+			result = append(result, decision)
+		}
+	}
+	return result
+}
+
+func writeToolsJSON(w io.Writer, decisions []inventory.ToolDecision, explain bool) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if explain {
+		return encoder.Encode(decisions)
+	}
+
+	type tool struct {
+		Name    string              `json:"name"`
+		Toolset inventory.ToolsetID `json:"toolset"`
+	}
+	tools := make([]tool, 0, len(decisions))
+	for _, decision := range decisions {
+		tools = append(tools, tool{Name: decision.Tool, Toolset: decision.Toolset})
+	}
+	return encoder.Encode(tools)
+}
+
+func writeToolsMarkdown(w io.Writer, decisions []inventory.ToolDecision, explain bool) {
+	if !explain {
+		_, _ = fmt.Fprintln(w, "| Tool | Toolset |")
+		_, _ = fmt.Fprintln(w, "| --- | --- |")
+		for _, decision := range decisions {
+			_, _ = fmt.Fprintf(w, "| `%s` | %s |\n", decision.Tool, decision.Toolset)
+		}
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "| Tool | Toolset | Enabled | Decided by | Reason | Trace |")
+	_, _ = fmt.Fprintln(w, "| --- | --- | --- | --- | --- | --- |")
+	for _, decision := range decisions {
+		enabled := "no"
+		if decision.Enabled {
+			enabled = "yes"
+		}
+		steps := make([]string, 0, len(decision.Steps))
+		for _, step := range decision.Steps {
+			mark := "✗"
+			if step.Allowed {
+				mark = "✓"
+			}
+			text := step.Reason
+			if step.Error != "" {
+				text += ": " + step.Error
+			}
+			steps = append(steps, fmt.Sprintf("%s %s", mark, text))
+		}
+		_, _ = fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s | %s |\n",
+			decision.Tool, decision.Toolset, enabled, decision.Stage,
+			escapeTableCell(decision.Reason), escapeTableCell(strings.Join(steps, " → ")))
+	}
+}
+
+// escapeTableCell escapes the characters that would break a markdown table cell.
+func escapeTableCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,321 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/inventory"
+)
+
+// doctorProbeTimeout bounds each request the doctor makes.
+const doctorProbeTimeout = 10 * time.Second
+
+// doctor writes a diagnostic report and counts the problems it finds.
+type doctor struct {
+	w        io.Writer
+	problems int
+}
+
+func (d *doctor) section(title string) {
+	fmt.Fprintf(d.w, "\n%s\n", title)
+}
+
+func (d *doctor) ok(format string, args ...any) {
+	fmt.Fprintf(d.w, "  ✓ %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(format string, args ...any) {
+	fmt.Fprintf(d.w, "  ! %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) fail(format string, args ...any) {
+	d.problems++
+	fmt.Fprintf(d.w, "  ✗ %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) info(format string, args ...any) {
+	fmt.Fprintf(d.w, "    %s\n", fmt.Sprintf(format, args...))
+}
+
+// RunDoctor checks the host, token and toolset configuration the server would run with and
+// writes a report to w, listing the tools that would be enabled and why the others are
+// hidden. It returns an error if any check failed.
+func RunDoctor(ctx context.Context, cfg StdioServerConfig, w io.Writer) error {
+	d := &doctor{w: w}
+	fmt.Fprintf(w, "github-mcp-server %s\n", cfg.Version)
+
+	d.section("Host")
+	apiHost, err := parseAPIHost(cfg.Host, cfg.EndpointOverrides)
+	if err != nil {
+		d.fail("failed to parse API host: %v", err)
+		return fmt.Errorf("doctor found %d problem(s)", d.problems)
+	}
+	d.ok("%s", describeHost(cfg.Host))
+	d.info("REST:    %s", apiHost.baseRESTURL)
+	d.info("GraphQL: %s", apiHost.graphqlURL)
+	d.info("Uploads: %s", apiHost.uploadURL)
+	d.info("Raw:     %s", apiHost.rawURL)
+	if u, ok := ghesHostURL(cfg.Host); ok {
+		switch {
+		case cfg.EndpointOverrides.RawURL != "":
+			d.ok("subdomain isolation not checked, as the raw URL is overridden with %s", apiHost.rawURL)
+		case checkSubdomainIsolation(u.Scheme, u.Host):
+			d.ok("subdomain isolation is enabled (raw.%s answers)", u.Host)
+		default:
+			d.ok("subdomain isolation is disabled (raw.%s does not answer)", u.Host)
+		}
+	}
+
+	d.section("Connectivity")
+	probeConnectivity(ctx, d, apiHost)
+
+	d.section("Token")
+	tokenScopes := checkToken(ctx, d, cfg, apiHost)
+
+	d.section("Toolsets")
+	mcpCfg := inventoryConfig(cfg, tokenScopes)
+	inv := buildInventory(mcpCfg, resolveEnabledToolsets(mcpCfg))
+	reportToolsets(d, cfg, inv)
+
+	reportTools(ctx, d, inv)
+
+	if d.problems > 0 {
+		return fmt.Errorf("doctor found %d problem(s)", d.problems)
+	}
+	return nil
+}
+
+// describeHost names the kind of GitHub deployment host refers to.
+func describeHost(host string) string {
+	if host == "" {
+		return "github.com"
+	}
+	if u, err := url.Parse(host); err == nil {
+		switch {
+		case strings.HasSuffix(u.Hostname(), "github.com"):
+			return "github.com"
+		case strings.HasSuffix(u.Hostname(), "ghe.com"):
+			return fmt.Sprintf("GitHub Enterprise Cloud with data residency (%s)", u.Hostname())
+		}
+	}
+	return fmt.Sprintf("GitHub Enterprise Server (%s)", host)
+}
+
+// ghesHostURL returns the URL of host if it is a GitHub Enterprise Server.
+func ghesHostURL(host string) (*url.URL, bool) {
+	if host == "" {
+		return nil, false
+	}
+	u, err := url.Parse(host)
+	if err != nil || strings.HasSuffix(u.Hostname(), "github.com") || strings.HasSuffix(u.Hostname(), "ghe.com") {
+		return nil, false
+	}
+	return u, true
+}
+
+// probeConnectivity checks that the REST API answers, without authenticating.
+func probeConnectivity(ctx context.Context, d *doctor, host apiHost) {
+	ctx, cancel := context.WithTimeout(ctx, doctorProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host.baseRESTURL.String(), nil)
+	if err != nil {
+		d.fail("failed to create request: %v", err)
+		return
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		d.fail("REST API unreachable: %v", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		d.fail("REST API answered %s in %s", resp.Status, elapsed)
+		return
+	}
+	d.ok("REST API answered %s in %s", resp.Status, elapsed)
+}
+
+// checkToken validates the token and returns the scopes tools are filtered by, or nil if the
+// token does not report scopes.
+func checkToken(ctx context.Context, d *doctor, cfg StdioServerConfig, host apiHost) []string {
+	source := "GITHUB_PERSONAL_ACCESS_TOKEN"
+	tokenSource := cfg.TokenSource
+	switch {
+	case cfg.GitHubApp != nil:
+		source = fmt.Sprintf("GitHub App %d installation", cfg.GitHubApp.AppID)
+		appTokenSource, err := newAppTokenSource(host, *cfg.GitHubApp, newAppTransport(nil, host, nil), cfg.Version)
+		if err != nil {
+			d.fail("%v", err)
+			return nil
+		}
+		tokenSource = appTokenSource
+	case tokenSource != nil:
+		source = "--token-file or --token-command"
+	}
+
+	token := cfg.Token
+	if tokenSource != nil {
+		var err error
+		token, err = tokenSource.Token(ctx)
+		if err != nil {
+			d.fail("failed to get token from %s: %v", source, err)
+			return nil
+		}
+	}
+	if token == "" {
+		d.fail("no token configured; set GITHUB_PERSONAL_ACCESS_TOKEN, --token-file, --token-command or --app-id")
+		return nil
+	}
+	d.ok("%s from %s", describeToken(token), source)
+
+	ctx, cancel := context.WithTimeout(ctx, doctorProbeTimeout)
+	defer cancel()
+	tokenScopes, err := fetchTokenScopesForHost(ctx, token, host)
+	if err != nil {
+		d.fail("token check failed: %v", err)
+		return nil
+	}
+	d.ok("token is valid")
+
+	// Only classic personal access tokens report their scopes, so only they are filtered by scope
+	if !strings.HasPrefix(token, "ghp_") {
+		d.info("this kind of token does not report scopes, so tools are not filtered by scope")
+		return nil
+	}
+	if len(tokenScopes) == 0 {
+		d.warn("token has no scopes; only tools that work on public data are enabled")
+	} else {
+		d.ok("scopes: %s", strings.Join(tokenScopes, ", "))
+	}
+	return tokenScopes
+}
+
+// describeToken names the kind of token from its prefix.
+func describeToken(token string) string {
+	for _, kind := range []struct{ prefix, name string }{
+		{"ghp_", "classic personal access token"},
+		{"github_pat_", "fine-grained personal access token"},
+		{"gho_", "OAuth token"},
+		{"ghu_", "GitHub App user token"},
+		{"ghs_", "GitHub App installation token"},
+	} {
+		if strings.HasPrefix(token, kind.prefix) {
+			return kind.name
+		}
+	}
+	return "token"
+}
+
+func reportToolsets(d *doctor, cfg StdioServerConfig, inv *inventory.Inventory) {
+	enabled := inv.EnabledToolsetIDs()
+	if len(enabled) == 0 {
+		d.info("no toolsets enabled")
+	} else {
+		ids := make([]string, 0, len(enabled))
+		for _, id := range enabled {
+			ids = append(ids, string(id))
+		}
+		d.ok("enabled: %s", strings.Join(ids, ", "))
+	}
+	if unrecognized := inv.UnrecognizedToolsets(); len(unrecognized) > 0 {
+		d.fail("unrecognized toolsets ignored: %s", strings.Join(unrecognized, ", "))
+	}
+	if unrecognized := inv.UnrecognizedTools(); len(unrecognized) > 0 {
+		d.fail("unrecognized tools ignored: %s", strings.Join(unrecognized, ", "))
+	}
+	if cfg.DynamicToolsets {
+		d.info("dynamic toolsets: other toolsets can be enabled while the server runs")
+	}
+	if cfg.ReadOnly {
+		d.info("read-only: write tools are hidden")
+	}
+	if cfg.NoDestructive {
+		d.info("no destructive tools: tools marked destructive are hidden")
+	}
+	if len(cfg.ExcludeTools) > 0 {
+		d.info("excluded tools: %s", strings.Join(cfg.ExcludeTools, ", "))
+	}
+	if cfg.RepoPolicy != nil {
+		d.info("repository policy: %s", cfg.RepoPolicy)
+	}
+	if cfg.ConfirmTools != nil {
+		d.info("confirmation: calls of %s must be confirmed by the user", cfg.ConfirmTools)
+	}
+	if cfg.DryRun {
+		d.info("dry run: write tools return the requests they would send instead of sending them")
+	}
+	for _, ts := range inv.CustomToolsets() {
+		d.info("custom toolset %s: %s", ts.ID, strings.Join(ts.Tools, ", "))
+	}
+	if len(cfg.EnabledFeatures) > 0 {
+		d.info("features: %s", strings.Join(cfg.EnabledFeatures, ", "))
+	}
+}
+
+// reportTools lists the enabled tools by toolset and every hidden tool with the reason it was
+// excluded.
+func reportTools(ctx context.Context, d *doctor, inv *inventory.Inventory) {
+	decisions := inv.ExplainTools(ctx)
+	enabledNames := make(map[string]bool)
+	byToolset := make(map[inventory.ToolsetID][]string)
+	var toolsetIDs []inventory.ToolsetID
+	for _, decision := range decisions {
+		if !decision.Enabled {
+			continue
+		}
+		enabledNames[decision.Tool] = true
+		if _, ok := byToolset[decision.Toolset]; !ok {
+			toolsetIDs = append(toolsetIDs, decision.Toolset)
+		}
+		byToolset[decision.Toolset] = append(byToolset[decision.Toolset], decision.Tool)
+	}
+
+	var hidden []string
+	for _, decision := range decisions {
+		// Tools with several variants behind feature flags are listed once when one is enabled
+		if enabledNames[decision.Tool] {
+			continue
+		}
+		hidden = append(hidden, fmt.Sprintf("%s (%s): %s", decision.Tool, decision.Toolset, hiddenReason(inv, decision)))
+	}
+	sort.Strings(hidden)
+	hidden = slices.Compact(hidden)
+
+	d.section(fmt.Sprintf("Tools (%d enabled, %d hidden)", len(enabledNames), len(hidden)))
+	for _, id := range toolsetIDs {
+		d.info("%s: %s", id, strings.Join(byToolset[id], ", "))
+	}
+	if len(hidden) > 0 {
+		fmt.Fprintf(d.w, "\n  Hidden tools\n")
+		for _, line := range hidden {
+			d.info("%s", line)
+		}
+	}
+}
+
+// hiddenReason explains why a tool is not enabled, naming the scopes it needs when the token
+// scope filter excluded it.
+func hiddenReason(inv *inventory.Inventory, decision inventory.ToolDecision) string {
+	last := decision.Steps[len(decision.Steps)-1]
+	if last.Error != "" {
+		return fmt.Sprintf("%s: %s", last.Reason, last.Error)
+	}
+	if last.Filter == github.ToolScopeFilterName {
+		if tool, _, err := inv.FindToolByName(decision.Tool); err == nil {
+			return fmt.Sprintf("token lacks the required scopes (%s)", strings.Join(tool.RequiredScopes, ", "))
+		}
+	}
+	return decision.Reason
+}
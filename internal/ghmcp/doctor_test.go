@@ -0,0 +1,122 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDoctor(t *testing.T) {
+	t.Parallel()
+
+	// The REST API stub accepts one token, which has the read:org scope only
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.Header.Get("Authorization") != "Bearer ghp_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "read:org")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		name        string
+		cfg         StdioServerConfig
+		expectError bool
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name: "hidden tools with reasons",
+			cfg: StdioServerConfig{
+				Token:           "ghp_valid",
+				EnabledToolsets: []string{"issues", "notifications", "orgs", "bogus"},
+				ReadOnly:        true,
+			},
+			expectError: true,
+			contains: []string{
+				"REST API answered 200 OK",
+				"classic personal access token from GITHUB_PERSONAL_ACCESS_TOKEN",
+				"scopes: read:org",
+				"enabled: issues, notifications, orgs",
+				"unrecognized toolsets ignored: bogus",
+				"search_orgs",
+				"issue_write (issues): write tool hidden in read-only mode",
+				"list_notifications (notifications): token lacks the required scopes (notifications)",
+				"get_me (context): toolset context is not enabled",
+			},
+		},
+		{
+			name:        "invalid token",
+			cfg:         StdioServerConfig{Token: "ghp_expired"},
+			expectError: true,
+			contains:    []string{"token check failed: invalid or expired token"},
+		},
+		{
+			name:        "missing token",
+			cfg:         StdioServerConfig{},
+			expectError: true,
+			contains:    []string{"no token configured"},
+		},
+		{
+			name: "healthy configuration",
+			cfg: StdioServerConfig{
+				Token:           "ghp_valid",
+				EnabledToolsets: []string{"orgs"},
+			},
+			contains: []string{"Tools (1 enabled", "orgs: search_orgs"},
+		},
+		{
+			name: "GHES with an overridden raw URL",
+			cfg: StdioServerConfig{
+				Host:              "https://ghes.example.invalid",
+				EndpointOverrides: EndpointOverrides{RawURL: "https://files.example.invalid/raw"},
+				Token:             "ghp_valid",
+				EnabledToolsets:   []string{"orgs"},
+			},
+			contains: []string{
+				"Raw:     https://files.example.invalid/raw/",
+				"Uploads: https://ghes.example.invalid/api/uploads/",
+				"subdomain isolation not checked, as the raw URL is overridden with https://files.example.invalid/raw/",
+			},
+			excludes: []string{"raw.ghes.example.invalid"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := tc.cfg
+			cfg.EndpointOverrides.RESTURL = ts.URL
+
+			var out bytes.Buffer
+			err := RunDoctor(context.Background(), cfg, &out)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tc.contains {
+				assert.Contains(t, out.String(), expected)
+			}
+			for _, unexpected := range tc.excludes {
+				assert.NotContains(t, out.String(), unexpected)
+			}
+		})
+	}
+}
+
+func TestDescribeHost(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "github.com", describeHost(""))
+	assert.Equal(t, "github.com", describeHost("https://github.com"))
+	assert.Equal(t, "GitHub Enterprise Cloud with data residency (tenant.ghe.com)", describeHost("https://tenant.ghe.com"))
+	assert.Equal(t, "GitHub Enterprise Server (https://github.example.com)", describeHost("https://github.example.com"))
+}
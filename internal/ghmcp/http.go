@@ -0,0 +1,266 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/metrics"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultHTTPListenAddress is the address the HTTP server listens on when none is configured.
+// It only binds to the loopback interface so the server is not exposed to the network by accident.
+const DefaultHTTPListenAddress = "127.0.0.1:8082"
+
+// DefaultHTTPBasePath is the path the MCP endpoint is served on when none is configured.
+const DefaultHTTPBasePath = "/mcp"
+
+// httpShutdownTimeout bounds how long in-flight requests are given to finish on shutdown.
+const httpShutdownTimeout = 10 * time.Second
+
+type HTTPServerConfig struct {
+	// StdioServerConfig holds the server settings shared with the stdio transport.
+	// EnableCommandLogging is not supported over HTTP and is ignored.
+	StdioServerConfig
+
+	// ListenAddress is the TCP address to listen on (e.g. 127.0.0.1:8082)
+	ListenAddress string
+
+	// BasePath is the URL path the MCP endpoint is served on (e.g. /mcp)
+	BasePath string
+
+	// SessionTimeout closes sessions that have been idle for this long.
+	// Zero means idle sessions are never closed.
+	SessionTimeout time.Duration
+
+	// Stateless disables session tracking, so every request is handled
+	// with a temporary session and server-to-client requests are rejected.
+	Stateless bool
+
+	// JSONResponse returns application/json responses instead of text/event-stream streams
+	JSONResponse bool
+
+	// MultiTenant serves each request with the GitHub token from its Authorization header
+	// instead of the server's own token, so one deployment can be shared by many users.
+	MultiTenant bool
+}
+
+// RunHTTPServer serves the MCP server over the Streamable HTTP transport until
+// an interrupt or termination signal is received.
+func RunHTTPServer(cfg HTTPServerConfig) error {
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	t, dumpTranslations := translations.TranslationHelper()
+
+	logger, err := newLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
+	}
+	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "transport", "http", "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode, "multiTenant", cfg.MultiTenant)
+
+	shutdownTracing, err := setupTracing(ctx, cfg.StdioServerConfig, logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	serverMetrics, err := serveMetrics(ctx, cfg.StdioServerConfig, logger)
+	if err != nil {
+		return err
+	}
+
+	if cfg.EnableCommandLogging {
+		logger.Warn("command logging is not supported by the HTTP transport and will be ignored")
+	}
+
+	handler, err := newHTTPHandler(ctx, cfg, t, logger, serverMetrics)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ExportTranslations {
+		// Once server is initialized, all translations are loaded
+		dumpTranslations()
+	}
+
+	basePath, err := normalizeBasePath(cfg.BasePath)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(basePath, handler)
+
+	listenAddress := cfg.ListenAddress
+	if listenAddress == "" {
+		listenAddress = DefaultHTTPListenAddress
+	}
+
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listenAddress, err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- httpServer.Serve(listener)
+	}()
+
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on http://%s%s\n", listener.Addr(), basePath)
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down server", "signal", "context done")
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("error running server", "error", err)
+			return fmt.Errorf("error running server: %w", err)
+		}
+		return nil
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down HTTP server: %w", err)
+	}
+
+	return nil
+}
+
+// newHTTPHandler creates the Streamable HTTP handler, either serving every session
+// from a single server with the configured token or, in multi-tenant mode, with
+// the token on each request.
+func newHTTPHandler(ctx context.Context, cfg HTTPServerConfig, t translations.TranslationHelperFunc, logger *slog.Logger, serverMetrics *metrics.Metrics) (http.Handler, error) {
+	opts := &mcp.StreamableHTTPOptions{
+		Stateless:      cfg.Stateless,
+		JSONResponse:   cfg.JSONResponse,
+		Logger:         logger,
+		SessionTimeout: cfg.SessionTimeout,
+	}
+
+	if cfg.MultiTenant {
+		if cfg.GitHubApp != nil || cfg.TokenSource != nil {
+			return nil, errors.New("server-side credentials are not supported in multi-tenant mode, tokens are taken from each request")
+		}
+		responseCache, err := newResponseCache(cfg.StdioServerConfig)
+		if err != nil {
+			return nil, err
+		}
+		auditLog, err := newAuditLog(cfg.StdioServerConfig)
+		if err != nil {
+			return nil, err
+		}
+		baseTransport, err := newCassetteTransport(cfg.StdioServerConfig, logger)
+		if err != nil {
+			return nil, err
+		}
+		mt, err := newMultiTenantServer(MCPServerConfig{
+			Version:           cfg.Version,
+			Host:              cfg.Host,
+			EndpointOverrides: cfg.EndpointOverrides,
+			EnabledToolsets:   cfg.EnabledToolsets,
+			EnabledTools:      cfg.EnabledTools,
+			ExcludeTools:      cfg.ExcludeTools,
+			CustomToolsets:    cfg.CustomToolsets,
+			EnabledFeatures:   cfg.EnabledFeatures,
+			DynamicToolsets:   cfg.DynamicToolsets,
+			ReadOnly:          cfg.ReadOnly,
+			NoDestructive:     cfg.NoDestructive,
+			Translator:        t,
+			ContentWindowSize: cfg.ContentWindowSize,
+			LockdownMode:      cfg.LockdownMode,
+			Logger:            logger,
+			RepoAccessTTL:     cfg.RepoAccessCacheTTL,
+			RateLimitMaxWait:  cfg.RateLimitMaxWait,
+			ResponseCache:     responseCache,
+			Metrics:           serverMetrics,
+			AuditLog:          auditLog,
+			Limits:            newLimiter(cfg.StdioServerConfig),
+			RepoPolicy:        cfg.RepoPolicy,
+			DryRun:            cfg.DryRun,
+			ConfirmTools:      cfg.ConfirmTools,
+			BaseTransport:     baseTransport,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return mt.handler(opts), nil
+	}
+
+	ghServer, err := newLocalMCPServer(ctx, cfg.StdioServerConfig, t, logger, serverMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
+		// All sessions share a single server, and therefore a single inventory and set of clients
+		return ghServer
+	}, opts), nil
+}
+
+// serveMetrics serves Prometheus metrics on the configured address until ctx is done, or
+// returns nil metrics if no address is configured.
+func serveMetrics(ctx context.Context, cfg StdioServerConfig, logger *slog.Logger) (*metrics.Metrics, error) {
+	if cfg.MetricsAddress == "" {
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", cfg.MetricsAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s for metrics: %w", cfg.MetricsAddress, err)
+	}
+
+	m := metrics.New()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	metricsServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := metricsServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("error serving metrics", "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = metricsServer.Close()
+	}()
+	logger.Info("serving metrics", "address", listener.Addr().String())
+
+	return m, nil
+}
+
+// normalizeBasePath ensures the base path starts with a slash and has no trailing slash,
+// so that it matches exactly one route on the mux.
+func normalizeBasePath(basePath string) (string, error) {
+	if basePath == "" {
+		return DefaultHTTPBasePath, nil
+	}
+	if strings.ContainsAny(basePath, " ?#") {
+		return "", fmt.Errorf("invalid base path: %q", basePath)
+	}
+	basePath = "/" + strings.Trim(basePath, "/")
+	return basePath, nil
+}
@@ -0,0 +1,39 @@
+package ghmcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBasePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		basePath    string
+		expected    string
+		expectError bool
+	}{
+		{name: "empty uses default", basePath: "", expected: DefaultHTTPBasePath},
+		{name: "already normalized", basePath: "/mcp", expected: "/mcp"},
+		{name: "missing leading slash", basePath: "mcp", expected: "/mcp"},
+		{name: "trailing slash removed", basePath: "/github/mcp/", expected: "/github/mcp"},
+		{name: "root", basePath: "/", expected: "/"},
+		{name: "query is rejected", basePath: "/mcp?x=1", expectError: true},
+		{name: "whitespace is rejected", basePath: "/my mcp", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := normalizeBasePath(tc.basePath)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
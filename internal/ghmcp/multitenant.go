@@ -0,0 +1,268 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// tokenVerificationTTL is how long a verified token and its scopes are reused
+// before the token is checked against the GitHub API again.
+const tokenVerificationTTL = 5 * time.Minute
+
+const (
+	// maxCachedServers bounds the servers kept for distinct token scope sets.
+	maxCachedServers = 64
+	// maxCachedDeps bounds the GitHub clients kept for distinct tokens.
+	maxCachedDeps = 1024
+)
+
+// errMissingBearerToken is returned when a request in multi-tenant mode carries no bearer token.
+var errMissingBearerToken = errors.New("missing bearer token in Authorization header")
+
+// multiTenantServer serves many users from one deployment. Every request brings its own
+// GitHub token, which is used to build the GitHub clients for that request only.
+// Tokens with the same OAuth scopes see the same tools, so servers are shared per scope set,
+// while clients, and the rate limits they track, are shared per token.
+type multiTenantServer struct {
+	cfg      MCPServerConfig
+	apiHost  apiHost
+	verifier *tokenVerifier
+
+	servers *lruCache[*mcp.Server]             // scope set key -> server
+	deps    *lruCache[github.ToolDependencies] // token hash -> dependencies
+}
+
+func newMultiTenantServer(cfg MCPServerConfig) (*multiTenantServer, error) {
+	if cfg.LockdownMode {
+		// The repo access cache is shared by every request and records what the viewer can access,
+		// so it cannot be safely reused across users.
+		return nil, errors.New("lockdown mode is not supported in multi-tenant mode")
+	}
+	if cfg.DynamicToolsets {
+		// Servers, and the inventory that dynamic toolsets change, are shared by every token
+		// with the same scopes, so one user could change the tools of another.
+		return nil, errors.New("dynamic toolsets are not supported in multi-tenant mode")
+	}
+
+	apiHost, err := parseAPIHost(cfg.Host, cfg.EndpointOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	return &multiTenantServer{
+		cfg:     cfg,
+		apiHost: apiHost,
+		verifier: newTokenVerifier(scopes.NewFetcher(scopes.FetcherOptions{
+			APIHost: apiHost.baseRESTURL.String(),
+		}), tokenVerificationTTL),
+		servers: newLRUCache[*mcp.Server](maxCachedServers),
+		deps:    newLRUCache[github.ToolDependencies](maxCachedDeps),
+	}, nil
+}
+
+// handler wraps the streamable HTTP handler so that only requests with a valid GitHub token are served.
+func (s *multiTenantServer) handler(opts *mcp.StreamableHTTPOptions) http.Handler {
+	streamable := mcp.NewStreamableHTTPHandler(s.serverForRequest, opts)
+	return auth.RequireBearerToken(s.verifier.verify, nil)(streamable)
+}
+
+// serverForRequest returns the server for the scopes of the token on the request,
+// creating it on first use.
+func (s *multiTenantServer) serverForRequest(r *http.Request) *mcp.Server {
+	tokenInfo := auth.TokenInfoFromContext(r.Context())
+	if tokenInfo == nil {
+		return nil
+	}
+
+	// Only classic PATs (ghp_ prefix) return OAuth scopes, so other tokens are not scope filtered.
+	var tokenScopes []string
+	key := "*"
+	token, _ := bearerToken(r.Header)
+	if strings.HasPrefix(token, "ghp_") {
+		tokenScopes = slices.Clone(tokenInfo.Scopes)
+		slices.Sort(tokenScopes)
+		key = strings.Join(tokenScopes, ",")
+	}
+
+	// Evicted servers keep serving the sessions they created, as sessions hold their server
+	server, _ := s.servers.getOrCreate(key, func() (*mcp.Server, error) {
+		cfg := s.cfg
+		cfg.TokenScopes = tokenScopes
+		return newMCPServer(cfg, nil, s.resolveDeps), nil
+	})
+	return server
+}
+
+// resolveDeps returns the GitHub clients for the token on a request, building them on the
+// first request with the token.
+func (s *multiTenantServer) resolveDeps(_ context.Context, req mcp.Request) (github.ToolDependencies, error) {
+	extra := req.GetExtra()
+	if extra == nil {
+		return nil, errMissingBearerToken
+	}
+	token, ok := bearerToken(extra.Header)
+	if !ok {
+		return nil, errMissingBearerToken
+	}
+
+	return s.deps.getOrCreate(hashToken(token), func() (github.ToolDependencies, error) {
+		cfg := s.cfg
+		cfg.Token = token
+		clients, err := createGitHubClients(cfg, s.apiHost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub clients: %w", err)
+		}
+
+		deps := github.NewBaseDeps(
+			clients.rest,
+			clients.gql,
+			clients.raw,
+			clients.repoAccess,
+			cfg.Translator,
+			github.FeatureFlags{LockdownMode: cfg.LockdownMode},
+			cfg.ContentWindowSize,
+		)
+		deps.RateLimits = clients.rateLimits
+		return deps, nil
+	})
+}
+
+// lruCache is a map bounded to a maximum number of entries, dropping the least recently used
+// entry to make room for a new one.
+type lruCache[T any] struct {
+	max int
+
+	mu      sync.Mutex
+	entries map[string]*lruEntry[T]
+	// clock orders the uses of entries
+	clock uint64
+}
+
+type lruEntry[T any] struct {
+	value    T
+	lastUsed uint64
+}
+
+func newLRUCache[T any](maxEntries int) *lruCache[T] {
+	return &lruCache[T]{
+		max:     maxEntries,
+		entries: make(map[string]*lruEntry[T]),
+	}
+}
+
+// getOrCreate returns the value for key, creating it with create if there is none. Errors
+// from create are returned and nothing is cached.
+func (c *lruCache[T]) getOrCreate(key string, create func() (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clock++
+	if entry, ok := c.entries[key]; ok {
+		entry.lastUsed = c.clock
+		return entry.value, nil
+	}
+
+	value, err := create()
+	if err != nil {
+		return value, err
+	}
+	if len(c.entries) >= c.max {
+		var oldestKey string
+		var oldest uint64
+		for k, entry := range c.entries {
+			if oldestKey == "" || entry.lastUsed < oldest {
+				oldestKey, oldest = k, entry.lastUsed
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+	c.entries[key] = &lruEntry[T]{value: value, lastUsed: c.clock}
+	return value, nil
+}
+
+// len returns the number of cached entries.
+func (c *lruCache[T]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// tokenVerifier checks bearer tokens against the GitHub API and caches the result,
+// so that every HTTP request does not cost an extra API call.
+type tokenVerifier struct {
+	fetcher *scopes.Fetcher
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*auth.TokenInfo // token hash -> verified token info
+}
+
+func newTokenVerifier(fetcher *scopes.Fetcher, ttl time.Duration) *tokenVerifier {
+	return &tokenVerifier{
+		fetcher: fetcher,
+		ttl:     ttl,
+		entries: make(map[string]*auth.TokenInfo),
+	}
+}
+
+// verify implements auth.TokenVerifier. The token hash is used as the user ID, so the
+// streamable HTTP handler rejects requests that reuse another token's session.
+func (v *tokenVerifier) verify(ctx context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+	key := hashToken(token)
+
+	v.mu.Lock()
+	info, ok := v.entries[key]
+	v.mu.Unlock()
+	if ok && time.Now().Before(info.Expiration) {
+		return info, nil
+	}
+
+	tokenScopes, err := v.fetcher.FetchTokenScopes(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", auth.ErrInvalidToken, err)
+	}
+
+	info = &auth.TokenInfo{
+		Scopes:     tokenScopes,
+		UserID:     key,
+		Expiration: time.Now().Add(v.ttl),
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// Drop expired entries while we hold the lock so the cache does not grow without bound
+	for k, entry := range v.entries {
+		if time.Now().After(entry.Expiration) {
+			delete(v.entries, k)
+		}
+	}
+	v.entries[key] = info
+	return info, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(header http.Header) (string, bool) {
+	fields := strings.Fields(header.Get("Authorization"))
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+		return "", false
+	}
+	return fields[1], true
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectedToken string
+		expectedOK    bool
+	}{
+		{name: "bearer token", authorization: "Bearer ghp_abc", expectedToken: "ghp_abc", expectedOK: true},
+		{name: "lowercase scheme", authorization: "bearer ghp_abc", expectedToken: "ghp_abc", expectedOK: true},
+		{name: "missing header", authorization: "", expectedOK: false},
+		{name: "basic auth", authorization: "Basic dXNlcjpwYXNz", expectedOK: false},
+		{name: "missing token", authorization: "Bearer", expectedOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.authorization != "" {
+				header.Set("Authorization", tc.authorization)
+			}
+			token, ok := bearerToken(header)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
+
+func TestTokenVerifier(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer ghp_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(scopes.OAuthScopesHeader, "repo, read:org")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	verifier := newTokenVerifier(scopes.NewFetcher(scopes.FetcherOptions{APIHost: ts.URL}), time.Minute)
+
+	info, err := verifier.verify(context.Background(), "ghp_valid", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo", "read:org"}, info.Scopes)
+	assert.Equal(t, hashToken("ghp_valid"), info.UserID)
+	assert.NotContains(t, info.UserID, "ghp_valid")
+	assert.True(t, info.Expiration.After(time.Now()))
+
+	// A second verification is served from the cache
+	_, err = verifier.verify(context.Background(), "ghp_valid", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = verifier.verify(context.Background(), "ghp_invalid", nil)
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestNewMultiTenantServer_RejectsLockdownMode(t *testing.T) {
+	t.Parallel()
+
+	_, err := newMultiTenantServer(MCPServerConfig{
+		Version:      "test",
+		Translator:   translations.NullTranslationHelper,
+		LockdownMode: true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lockdown mode")
+}
+
+func TestNewMultiTenantServer_RejectsDynamicToolsets(t *testing.T) {
+	t.Parallel()
+
+	_, err := newMultiTenantServer(MCPServerConfig{
+		Version:         "test",
+		Translator:      translations.NullTranslationHelper,
+		DynamicToolsets: true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dynamic toolsets")
+}
+
+func TestMultiTenantServer_ResolveDepsPerToken(t *testing.T) {
+	t.Parallel()
+
+	s, err := newMultiTenantServer(MCPServerConfig{
+		Version:    "test",
+		Translator: translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	requestWithToken := func(token string) mcp.Request {
+		return &mcp.CallToolRequest{Extra: &mcp.RequestExtra{Header: http.Header{"Authorization": []string{"Bearer " + token}}}}
+	}
+
+	first, err := s.resolveDeps(context.Background(), requestWithToken("token-a"))
+	require.NoError(t, err)
+	again, err := s.resolveDeps(context.Background(), requestWithToken("token-a"))
+	require.NoError(t, err)
+	other, err := s.resolveDeps(context.Background(), requestWithToken("token-b"))
+	require.NoError(t, err)
+
+	// Requests with the same token share clients, and so their rate limit tracking
+	assert.Same(t, first, again)
+	assert.NotSame(t, first, other)
+
+	_, err = s.resolveDeps(context.Background(), &mcp.CallToolRequest{Extra: &mcp.RequestExtra{Header: http.Header{}}})
+	require.ErrorIs(t, err, errMissingBearerToken)
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newLRUCache[int](2)
+	value := func(v int) func() (int, error) {
+		return func() (int, error) { return v, nil }
+	}
+
+	got, err := cache.getOrCreate("a", value(1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+	_, _ = cache.getOrCreate("b", value(2))
+
+	// Using a keeps it, so adding c evicts b
+	got, _ = cache.getOrCreate("a", value(10))
+	assert.Equal(t, 1, got)
+	_, _ = cache.getOrCreate("c", value(3))
+	assert.Equal(t, 2, cache.len())
+	got, _ = cache.getOrCreate("b", value(20))
+	assert.Equal(t, 20, got, "expected b to have been evicted")
+
+	// Errors are not cached
+	_, err = cache.getOrCreate("d", func() (int, error) { return 0, errors.New("boom") })
+	require.Error(t, err)
+	got, _ = cache.getOrCreate("d", value(4))
+	assert.Equal(t, 4, got)
+}
@@ -2,24 +2,36 @@ package ghmcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/cassette"
+	"github.com/github/github-mcp-server/pkg/confirm"
+	"github.com/github/github-mcp-server/pkg/dryrun"
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/httpcache"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/limits"
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/metrics"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/repopolicy"
 	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/tokensource"
+	"github.com/github/github-mcp-server/pkg/tracing"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -33,9 +45,16 @@ type MCPServerConfig struct {
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
+	// EndpointOverrides replaces individual API URLs derived from Host
+	EndpointOverrides EndpointOverrides
+
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenSource provides the token to authenticate with the GitHub API, for tokens
+	// that are refreshed while the server runs. When set, Token is ignored.
+	TokenSource tokensource.TokenSource
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -44,6 +63,14 @@ type MCPServerConfig struct {
 	// When specified, these tools are registered in addition to any specified toolset tools
 	EnabledTools []string
 
+	// ExcludeTools is a list of tools to leave out, even if their toolset is enabled or they
+	// are listed in EnabledTools. Like EnabledTools, it accepts glob patterns such as "*delete*"
+	ExcludeTools []string
+
+	// CustomToolsets are toolsets declared in configuration, grouping tools from any
+	// toolsets. Their IDs can be used wherever EnabledToolsets accepts a toolset ID
+	CustomToolsets []inventory.CustomToolset
+
 	// EnabledFeatures is a list of feature flags that are enabled
 	// Items with FeatureFlagEnable matching an entry in this list will be available
 	EnabledFeatures []string
@@ -55,6 +82,9 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// NoDestructive hides the tools marked destructive, while other write tools stay available
+	NoDestructive bool
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 
@@ -69,6 +99,39 @@ type MCPServerConfig struct {
 	// RepoAccessTTL overrides the default TTL for repository access cache entries.
 	RepoAccessTTL *time.Duration
 
+	// RateLimitMaxWait is the longest a single GitHub API request may wait for rate limits
+	// to clear before the rate limited response is returned. Zero disables waiting.
+	RateLimitMaxWait time.Duration
+
+	// ResponseCache stores GitHub API responses for revalidation with conditional requests.
+	// Nil disables response caching.
+	ResponseCache httpcache.Store
+
+	// Metrics records tool calls and GitHub API requests. Nil disables metrics.
+	Metrics *metrics.Metrics
+
+	// AuditLog records every call of a write tool. Nil disables audit logging.
+	AuditLog *audit.Logger
+
+	// Limits bounds the duration and concurrency of tool calls. Nil imposes no limits.
+	Limits *limits.Limiter
+
+	// RepoPolicy restricts the repositories tools and resources may act on. Nil allows
+	// every repository.
+	RepoPolicy *repopolicy.Policy
+
+	// DryRun runs every call of a write tool as a dry run, returning the GitHub API request
+	// the tool would send instead of sending it. Single calls can ask for a dry run regardless.
+	DryRun bool
+
+	// ConfirmTools lists the tools whose calls the user must confirm through elicitation
+	// before they run. Nil confirms nothing.
+	ConfirmTools *confirm.List
+
+	// BaseTransport sends the GitHub API requests, such as a cassette recorder or replayer.
+	// http.DefaultTransport is used if nil.
+	BaseTransport http.RoundTripper
+
 	// TokenScopes contains the OAuth scopes available to the token.
 	// When non-nil, tools requiring scopes not in this list will be hidden.
 	// This is used for PAT scope filtering where we can't issue scope challenges.
@@ -79,15 +142,66 @@ type MCPServerConfig struct {
 type githubClients struct {
 	rest       *gogithub.Client
 	gql        *githubv4.Client
-	gqlHTTP    *http.Client // retained for middleware to modify transport
 	raw        *raw.Client
 	repoAccess *lockdown.RepoAccessCache
+	rateLimits *ratelimit.Tracker
 }
 
 // createGitHubClients creates all the GitHub API clients needed by the server.
 func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients, error) {
+	// All clients share one authenticating transport, so a refreshed token is used everywhere at once
+	source := cfg.TokenSource
+	if source == nil {
+		source = tokensource.Static(cfg.Token)
+	}
+	baseTransport := cfg.BaseTransport
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	rateLimits := ratelimit.NewTracker()
+	var transport http.RoundTripper = &ratelimit.Transport{
+		Base:    baseTransport,
+		Tracker: rateLimits,
+		MaxWait: cfg.RateLimitMaxWait,
+	}
+	if cfg.ResponseCache != nil {
+		// Cached responses are keyed by credential, so the cache sits below the authenticating transport
+		transport = &httpcache.Transport{
+			Base:   transport,
+			Store:  cfg.ResponseCache,
+			RawURL: apiHost.rawURL,
+		}
+	}
+	authTransport := &tokensource.Transport{
+		Source: source,
+		Base:   transport,
+	}
+	var toolTransport http.RoundTripper = authTransport
+	if cfg.AuditLog != nil {
+		toolTransport = &audit.Transport{Base: toolTransport}
+	}
+	if cfg.Metrics != nil {
+		toolTransport = &metrics.Transport{
+			Base:    toolTransport,
+			Metrics: cfg.Metrics,
+			RawURL:  apiHost.rawURL,
+		}
+	}
+	// Above the metrics so that the requests held back by dry runs are not counted as failures
+	toolTransport = &dryrun.Transport{Base: toolTransport}
+	// Spans cover the whole request as the tool sees it, including rate limit waits and retries
+	tracingTransport := &tracing.Transport{
+		Base:   toolTransport,
+		RawURL: apiHost.rawURL,
+	}
+
+	// The user agent names the MCP client of the session making each request
+	clientTransport := &userAgentTransport{transport: tracingTransport}
+
 	// Construct REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{Transport: clientTransport})
+	// The rate limit transport waits for limits to reset, so the client must not reject requests up front
+	restClient.DisableRateLimitCheck = true
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -95,10 +209,7 @@ func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients,
 	// Construct GraphQL client
 	// We use NewEnterpriseClient unconditionally since we already parsed the API host
 	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
+		Transport: clientTransport,
 	}
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
 
@@ -115,14 +226,17 @@ func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients,
 			opts = append(opts, lockdown.WithTTL(*cfg.RepoAccessTTL))
 		}
 		repoAccessCache = lockdown.GetInstance(gqlClient, opts...)
+		if cfg.Metrics != nil {
+			cfg.Metrics.ObserveRepoAccessCache(repoAccessCache)
+		}
 	}
 
 	return &githubClients{
 		rest:       restClient,
 		gql:        gqlClient,
-		gqlHTTP:    gqlHTTPClient,
 		raw:        rawClient,
 		repoAccess: repoAccessCache,
+		rateLimits: rateLimits,
 	}, nil
 }
 
@@ -154,7 +268,7 @@ func resolveEnabledToolsets(cfg MCPServerConfig) []string {
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
-	apiHost, err := parseAPIHost(cfg.Host)
+	apiHost, err := parseAPIHost(cfg.Host, cfg.EndpointOverrides)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
@@ -164,6 +278,33 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		return nil, fmt.Errorf("failed to create GitHub clients: %w", err)
 	}
 
+	// Create dependencies for tool handlers
+	deps := github.NewBaseDeps(
+		clients.rest,
+		clients.gql,
+		clients.raw,
+		clients.repoAccess,
+		cfg.Translator,
+		github.FeatureFlags{LockdownMode: cfg.LockdownMode},
+		cfg.ContentWindowSize,
+	)
+	deps.RateLimits = clients.rateLimits
+
+	ghServer := newMCPServer(cfg, deps, func(_ context.Context, _ mcp.Request) (github.ToolDependencies, error) {
+		return deps, nil
+	})
+
+	return ghServer, nil
+}
+
+// depsResolver returns the ToolDependencies to inject into the context of a request.
+type depsResolver func(ctx context.Context, req mcp.Request) (github.ToolDependencies, error)
+
+// newMCPServer creates the MCP server and registers the inventory. Dependencies are
+// looked up through resolveDeps for every request, so they may be shared by all
+// requests or built from the request itself. registrationDeps is only used for
+// handlers that capture their dependencies at registration time.
+func newMCPServer(cfg MCPServerConfig, registrationDeps any, resolveDeps depsResolver) *mcp.Server {
 	enabledToolsets := resolveEnabledToolsets(cfg)
 
 	// For instruction generation, we need actual toolset names (not nil).
@@ -177,81 +318,154 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 	serverOpts := &mcp.ServerOptions{
 		Instructions: github.GenerateInstructions(instructionToolsets),
 		Logger:       cfg.Logger,
-		CompletionHandler: github.CompletionsHandler(func(_ context.Context) (*gogithub.Client, error) {
-			return clients.rest, nil
+		CompletionHandler: github.CompletionsHandler(func(ctx context.Context) (*gogithub.Client, error) {
+			deps, ok := github.DepsFromContext(ctx)
+			if !ok {
+				return nil, github.ErrDepsNotInContext
+			}
+			return deps.GetClient(ctx)
 		}),
 	}
 
 	// In dynamic mode, explicitly advertise capabilities since tools/resources/prompts
-	// may be enabled at runtime even if none are registered initially.
+	// may be enabled at runtime even if none are registered initially, and the lists
+	// change as toolsets are enabled and disabled.
 	if cfg.DynamicToolsets {
 		serverOpts.Capabilities = &mcp.ServerCapabilities{
-			Tools:     &mcp.ToolCapabilities{},
-			Resources: &mcp.ResourceCapabilities{},
-			Prompts:   &mcp.PromptCapabilities{},
+			Tools:     &mcp.ToolCapabilities{ListChanged: true},
+			Resources: &mcp.ResourceCapabilities{ListChanged: true},
+			Prompts:   &mcp.PromptCapabilities{ListChanged: true},
 		}
 	}
 
 	ghServer := github.NewServer(cfg.Version, serverOpts)
 
+	// Build and register the tool/resource/prompt inventory
+	inventory := buildInventory(cfg, enabledToolsets)
+
+	if unrecognized := inventory.UnrecognizedToolsets(); len(unrecognized) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: unrecognized toolsets ignored: %s\n", strings.Join(unrecognized, ", "))
+	}
+	if unrecognized := inventory.UnrecognizedTools(); len(unrecognized) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: unrecognized tools ignored: %s\n", strings.Join(unrecognized, ", "))
+	}
+
 	// Add middlewares
+	if cfg.Limits != nil {
+		// Added first so that it runs closest to the tool handlers, and the other middleware see the calls it rejects
+		ghServer.AddReceivingMiddleware(cfg.Limits.Middleware())
+	}
+	if cfg.Metrics != nil {
+		// Added before the GitHub errors are put in the context, so that it runs inside and can count them
+		var dynamicTools []string
+		if cfg.DynamicToolsets {
+			for _, tool := range github.DynamicTools(inventory) {
+				dynamicTools = append(dynamicTools, tool.Tool.Name)
+			}
+		}
+		ghServer.AddReceivingMiddleware(cfg.Metrics.Middleware(inventory, dynamicTools...))
+	}
 	ghServer.AddReceivingMiddleware(addGitHubAPIErrorToContext)
-	ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, clients.rest, clients.gqlHTTP))
 
-	// Create dependencies for tool handlers
-	deps := github.NewBaseDeps(
-		clients.rest,
-		clients.gql,
-		clients.raw,
-		clients.repoAccess,
-		cfg.Translator,
-		github.FeatureFlags{LockdownMode: cfg.LockdownMode},
-		cfg.ContentWindowSize,
-	)
+	ghServer.AddReceivingMiddleware(userAgentMiddleware(cfg.Version))
 
 	// Inject dependencies into context for all tool handlers
 	ghServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			deps, err := resolveDeps(ctx, req)
+			if err != nil {
+				return nil, err
+			}
 			return next(github.ContextWithDeps(ctx, deps), method, req)
 		}
 	})
 
-	// Build and register the tool/resource/prompt inventory
-	inventoryBuilder := github.NewInventory(cfg.Translator).
-		WithDeprecatedAliases(github.DeprecatedToolAliases).
-		WithReadOnly(cfg.ReadOnly).
-		WithToolsets(enabledToolsets).
-		WithTools(github.CleanTools(cfg.EnabledTools)).
-		WithFeatureChecker(createFeatureChecker(cfg.EnabledFeatures))
+	if cfg.ResponseCache != nil {
+		ghServer.AddReceivingMiddleware(invalidateResponseCacheMiddleware(cfg.ResponseCache, inventory))
+	}
 
-	// Apply token scope filtering if scopes are known (for PAT filtering)
-	if cfg.TokenScopes != nil {
-		inventoryBuilder = inventoryBuilder.WithFilter(github.CreateToolScopeFilter(cfg.TokenScopes))
+	if cfg.ConfirmTools != nil {
+		// Added before the repository policy so that the user is not asked to confirm calls it refuses
+		ghServer.AddReceivingMiddleware(confirm.Middleware(cfg.ConfirmTools, inventory))
 	}
 
-	inventory := inventoryBuilder.Build()
+	if cfg.RepoPolicy != nil {
+		// Added before the audit log so that refused calls are still audited
+		ghServer.AddReceivingMiddleware(github.RepoPolicyMiddleware(cfg.RepoPolicy, inventory))
+	}
 
-	if unrecognized := inventory.UnrecognizedToolsets(); len(unrecognized) > 0 {
-		fmt.Fprintf(os.Stderr, "Warning: unrecognized toolsets ignored: %s\n", strings.Join(unrecognized, ", "))
+	if cfg.AuditLog != nil {
+		ghServer.AddReceivingMiddleware(cfg.AuditLog.Middleware(inventory, cfg.Logger))
 	}
 
+	// Added after the audit log so that dry runs are recorded as such
+	ghServer.AddReceivingMiddleware(dryrun.Middleware(inventory, cfg.DryRun))
+
+	// Added after the other middleware so that it runs first and its span covers them
+	ghServer.AddReceivingMiddleware(tracing.Middleware(nil))
+
 	// Register GitHub tools/resources/prompts from the inventory.
 	// In dynamic mode with no explicit toolsets, this is a no-op since enabledToolsets
 	// is empty - users enable toolsets at runtime via the dynamic tools below (but can
 	// enable toolsets or tools explicitly that do need registration).
-	inventory.RegisterAll(context.Background(), ghServer, deps)
+	inventory.RegisterAll(context.Background(), ghServer, registrationDeps)
 
 	// Register dynamic toolset management tools (enable/disable) - these are separate
 	// meta-tools that control the inventory, not part of the inventory itself
 	if cfg.DynamicToolsets {
-		registerDynamicTools(ghServer, inventory, deps, cfg.Translator)
+		registerDynamicTools(ghServer, inventory, registrationDeps, cfg.Translator)
 	}
 
-	return ghServer, nil
+	return ghServer
+}
+
+// buildInventory builds the inventory of tools, resources and prompts filtered by the config.
+func buildInventory(cfg MCPServerConfig, enabledToolsets []string) *inventory.Inventory {
+	inventoryBuilder := github.NewInventory(cfg.Translator).
+		WithDeprecatedAliases(github.DeprecatedToolAliases).
+		WithReadOnly(cfg.ReadOnly).
+		WithNoDestructive(cfg.NoDestructive).
+		WithToolsets(enabledToolsets).
+		WithTools(github.CleanTools(cfg.EnabledTools)).
+		WithExcludeTools(github.CleanTools(cfg.ExcludeTools)).
+		WithCustomToolsets(cfg.CustomToolsets).
+		WithFeatureChecker(createFeatureChecker(cfg.EnabledFeatures))
+
+	// Apply token scope filtering if scopes are known (for PAT filtering)
+	if cfg.TokenScopes != nil {
+		inventoryBuilder = inventoryBuilder.WithNamedFilter(github.ToolScopeFilterName, github.CreateToolScopeFilter(cfg.TokenScopes))
+	}
+
+	return inventoryBuilder.Build()
+}
+
+// ToolInventory builds the inventory of tools the server would run with for cfg, without
+// fetching the token's scopes, so tools are not filtered by scope. RunDoctor applies the
+// scope filter too.
+func ToolInventory(cfg StdioServerConfig) *inventory.Inventory {
+	mcpCfg := inventoryConfig(cfg, nil)
+	return buildInventory(mcpCfg, resolveEnabledToolsets(mcpCfg))
+}
+
+// inventoryConfig returns the parts of the MCP server config that decide which tools are
+// enabled, for commands that inspect the inventory without running a server.
+func inventoryConfig(cfg StdioServerConfig, tokenScopes []string) MCPServerConfig {
+	return MCPServerConfig{
+		EnabledToolsets: cfg.EnabledToolsets,
+		EnabledTools:    cfg.EnabledTools,
+		ExcludeTools:    cfg.ExcludeTools,
+		CustomToolsets:  cfg.CustomToolsets,
+		EnabledFeatures: cfg.EnabledFeatures,
+		DynamicToolsets: cfg.DynamicToolsets,
+		ReadOnly:        cfg.ReadOnly,
+		NoDestructive:   cfg.NoDestructive,
+		Translator:      translations.NullTranslationHelper,
+		TokenScopes:     tokenScopes,
+	}
 }
 
 // registerDynamicTools adds the dynamic toolset enable/disable tools to the server.
-func registerDynamicTools(server *mcp.Server, inventory *inventory.Inventory, deps *github.BaseDeps, t translations.TranslationHelperFunc) {
+func registerDynamicTools(server *mcp.Server, inventory *inventory.Inventory, deps any, t translations.TranslationHelperFunc) {
 	dynamicDeps := github.DynamicToolDependencies{
 		Server:    server,
 		Inventory: inventory,
@@ -284,9 +498,19 @@ type StdioServerConfig struct {
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
+	// EndpointOverrides replaces individual API URLs derived from Host
+	EndpointOverrides EndpointOverrides
+
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenSource provides a token that is re-read while the server runs, such as from a
+	// file or a credential helper. When set, Token is ignored.
+	TokenSource tokensource.TokenSource
+
+	// GitHubApp authenticates as a GitHub App installation instead of with Token, when set
+	GitHubApp *GitHubAppConfig
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -295,6 +519,14 @@ type StdioServerConfig struct {
 	// When specified, these tools are registered in addition to any specified toolset tools
 	EnabledTools []string
 
+	// ExcludeTools is a list of tools to leave out, even if their toolset is enabled or they
+	// are listed in EnabledTools. Like EnabledTools, it accepts glob patterns such as "*delete*"
+	ExcludeTools []string
+
+	// CustomToolsets are toolsets declared in configuration, grouping tools from any
+	// toolsets. Their IDs can be used wherever EnabledToolsets accepts a toolset ID
+	CustomToolsets []inventory.CustomToolset
+
 	// EnabledFeatures is a list of feature flags that are enabled
 	// Items with FeatureFlagEnable matching an entry in this list will be available
 	EnabledFeatures []string
@@ -306,6 +538,9 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// NoDestructive indicates if we should leave out the tools marked destructive
+	NoDestructive bool
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -313,6 +548,9 @@ type StdioServerConfig struct {
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
+	// CommandLogRedaction is applied to the messages logged by command logging
+	CommandLogRedaction mcplog.Redaction
+
 	// Path to the log file if not stderr
 	LogFilePath string
 
@@ -324,6 +562,67 @@ type StdioServerConfig struct {
 
 	// RepoAccessCacheTTL overrides the default TTL for repository access cache entries.
 	RepoAccessCacheTTL *time.Duration
+
+	// RateLimitMaxWait is the longest a single GitHub API request may wait for rate limits to clear
+	RateLimitMaxWait time.Duration
+
+	// ResponseCacheSize is the most bytes of GitHub API responses to cache. Zero, the default,
+	// disables the cache.
+	ResponseCacheSize int64
+
+	// ResponseCacheDir keeps the response cache on disk in this directory instead of in memory, if set
+	ResponseCacheDir string
+
+	// OTLPEndpoint is the URL of an OTLP/HTTP collector to export traces to. Empty disables tracing.
+	OTLPEndpoint string
+
+	// MetricsAddress is the address to serve Prometheus metrics on at /metrics. Empty disables metrics.
+	MetricsAddress string
+
+	// AuditLogPath is the file to record calls of write tools in as JSON lines. Empty disables audit logging.
+	AuditLogPath string
+
+	// AuditLogMaxSize is the size in bytes at which the audit log is rotated. Zero disables rotation.
+	AuditLogMaxSize int64
+
+	// AuditLogMaxBackups is the number of rotated audit log files to keep
+	AuditLogMaxBackups int
+
+	// RecordCassette is a cassette file to record GitHub API requests and responses to
+	RecordCassette string
+
+	// ReplayCassette is a cassette file to serve GitHub API responses from instead of GitHub
+	ReplayCassette string
+
+	// ToolLimits bounds the duration and concurrency of tool calls
+	ToolLimits limits.Config
+
+	// RepoPolicy restricts the repositories tools and resources may act on. Nil allows
+	// every repository.
+	RepoPolicy *repopolicy.Policy
+
+	// DryRun returns the GitHub API requests write tools would send instead of sending them
+	DryRun bool
+
+	// ConfirmTools lists the tools whose calls the user must confirm before they run. Nil
+	// confirms nothing.
+	ConfirmTools *confirm.List
+}
+
+// GitHubAppConfig identifies a GitHub App installation to authenticate as.
+type GitHubAppConfig struct {
+	// AppID is the ID of the GitHub App
+	AppID int64
+
+	// PrivateKeyPath is the path to the PEM encoded private key of the GitHub App
+	PrivateKeyPath string
+
+	// InstallationID is the ID of the installation to authenticate as
+	InstallationID int64
+
+	// InstallationOwner is the organization or user the App is installed on.
+	// It is used to look up the installation when InstallationID is not set.
+	InstallationOwner string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -334,28 +633,145 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	var slogHandler slog.Handler
-	var logOutput io.Writer
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	logger, err := newLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
+	}
+	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
+
+	shutdownTracing, err := setupTracing(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	serverMetrics, err := serveMetrics(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	ghServer, err := newLocalMCPServer(ctx, cfg, t, logger, serverMetrics)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ExportTranslations {
+		// Once server is initialized, all translations are loaded
+		dumpTranslations()
+	}
+
+	// Start listening for messages
+	errC := make(chan error, 1)
+	go func() {
+		var transport mcp.Transport = &mcp.IOTransport{Reader: os.Stdin, Writer: os.Stdout}
+
+		if cfg.EnableCommandLogging {
+			transport = &mcplog.ProtocolTransport{
+				Transport: transport,
+				Logger:    logger,
+				Redaction: cfg.CommandLogRedaction,
+			}
+		}
+
+		// enable GitHub errors in the context
+		ctx := errors.ContextWithGitHubErrors(ctx)
+		errC <- ghServer.Run(ctx, transport)
+	}()
+
+	// Output github-mcp-server string
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down server", "signal", "context done")
+	case err := <-errC:
 		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
+			logger.Error("error running server", "error", err)
+			return fmt.Errorf("error running server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setupTracing starts exporting traces when an OTLP endpoint is configured. The returned
+// function flushes the remaining spans.
+func setupTracing(ctx context.Context, cfg StdioServerConfig, logger *slog.Logger) (func(), error) {
+	if cfg.OTLPEndpoint == "" {
+		return func() {}, nil
+	}
+
+	shutdown, err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:       cfg.OTLPEndpoint,
+		ServiceVersion: cfg.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("exporting traces", "endpoint", cfg.OTLPEndpoint)
+
+	return func() {
+		// The app context is done by now, so flushing gets its own deadline
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Warn("failed to flush traces", "error", err)
+		}
+	}, nil
+}
+
+// newLogger creates the server logger. When logFilePath is set, debug level logs
+// are appended to that file, otherwise info level logs are written to stderr.
+func newLogger(logFilePath string) (*slog.Logger, error) {
+	if logFilePath == "" {
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})), nil
+	}
+	file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return slog.New(slog.NewTextHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug})), nil
+}
+
+// newLocalMCPServer creates the MCP server for a locally run binary, where a single
+// token is known at startup. It is shared by the stdio and HTTP transports.
+func newLocalMCPServer(ctx context.Context, cfg StdioServerConfig, t translations.TranslationHelperFunc, logger *slog.Logger, serverMetrics *metrics.Metrics) (*mcp.Server, error) {
+	apiHost, err := parseAPIHost(cfg.Host, cfg.EndpointOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	baseTransport, err := newCassetteTransport(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource := cfg.TokenSource
+	if cfg.GitHubApp != nil {
+		appTokenSource, err := newAppTokenSource(apiHost, *cfg.GitHubApp, newAppTransport(baseTransport, apiHost, serverMetrics), cfg.Version)
+		if err != nil {
+			return nil, err
+		}
+		tokenSource = appTokenSource
+		logger.Info("authenticating as GitHub App installation", "appID", cfg.GitHubApp.AppID)
+	}
+
+	token := cfg.Token
+	if tokenSource != nil {
+		// Get the first token up front so misconfiguration fails at startup
+		token, err = tokenSource.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub token: %w", err)
 		}
-		logOutput = file
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelDebug})
-	} else {
-		logOutput = os.Stderr
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelInfo})
 	}
-	logger := slog.New(slogHandler)
-	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
 
 	// Fetch token scopes for scope-based tool filtering (PAT tokens only)
 	// Only classic PATs (ghp_ prefix) return OAuth scopes via X-OAuth-Scopes header.
 	// Fine-grained PATs and other token types don't support this, so we skip filtering.
 	var tokenScopes []string
-	if strings.HasPrefix(cfg.Token, "ghp_") {
-		fetchedScopes, err := fetchTokenScopesForHost(ctx, cfg.Token, cfg.Host)
+	if strings.HasPrefix(token, "ghp_") {
+		fetchedScopes, err := fetchTokenScopesForHost(ctx, token, apiHost)
 		if err != nil {
 			logger.Warn("failed to fetch token scopes, continuing without scope filtering", "error", err)
 		} else {
@@ -366,65 +782,195 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		logger.Debug("skipping scope filtering for non-PAT token")
 	}
 
+	// Tools were filtered by the scopes of the first token, so check that a rotated token still has them
+	if refreshing, ok := tokenSource.(*tokensource.Refreshing); ok && tokenScopes != nil {
+		refreshing.OnRotate(func(newToken string) {
+			recheckTokenScopes(context.Background(), newToken, apiHost, tokenScopes, logger)
+		})
+	}
+
+	responseCache, err := newResponseCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog, err := newAuditLog(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	ghServer, err := NewMCPServer(MCPServerConfig{
 		Version:           cfg.Version,
 		Host:              cfg.Host,
-		Token:             cfg.Token,
+		EndpointOverrides: cfg.EndpointOverrides,
+		Token:             token,
+		TokenSource:       tokenSource,
 		EnabledToolsets:   cfg.EnabledToolsets,
 		EnabledTools:      cfg.EnabledTools,
+		ExcludeTools:      cfg.ExcludeTools,
+		CustomToolsets:    cfg.CustomToolsets,
 		EnabledFeatures:   cfg.EnabledFeatures,
 		DynamicToolsets:   cfg.DynamicToolsets,
 		ReadOnly:          cfg.ReadOnly,
+		NoDestructive:     cfg.NoDestructive,
 		Translator:        t,
 		ContentWindowSize: cfg.ContentWindowSize,
 		LockdownMode:      cfg.LockdownMode,
 		Logger:            logger,
 		RepoAccessTTL:     cfg.RepoAccessCacheTTL,
+		RateLimitMaxWait:  cfg.RateLimitMaxWait,
+		ResponseCache:     responseCache,
+		Metrics:           serverMetrics,
+		AuditLog:          auditLog,
+		Limits:            newLimiter(cfg),
+		RepoPolicy:        cfg.RepoPolicy,
+		DryRun:            cfg.DryRun,
+		ConfirmTools:      cfg.ConfirmTools,
+		BaseTransport:     baseTransport,
 		TokenScopes:       tokenScopes,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
+		return nil, fmt.Errorf("failed to create MCP server: %w", err)
 	}
+	return ghServer, nil
+}
 
-	if cfg.ExportTranslations {
-		// Once server is initialized, all translations are loaded
-		dumpTranslations()
+// recheckTokenScopes fetches the scopes of a rotated token and warns when they differ from
+// the scopes the available tools were filtered by, as that only changes on restart.
+func recheckTokenScopes(ctx context.Context, token string, host apiHost, filteredScopes []string, logger *slog.Logger) {
+	if !strings.HasPrefix(token, "ghp_") {
+		logger.Warn("rotated token is not a classic personal access token; tools are still filtered by the scopes of the previous token")
+		return
 	}
 
-	// Start listening for messages
-	errC := make(chan error, 1)
-	go func() {
-		var in io.ReadCloser
-		var out io.WriteCloser
+	currentScopes, err := fetchTokenScopesForHost(ctx, token, host)
+	if err != nil {
+		logger.Warn("failed to fetch scopes of rotated token", "error", err)
+		return
+	}
 
-		in = os.Stdin
-		out = os.Stdout
+	previous := slices.Sorted(slices.Values(filteredScopes))
+	current := slices.Sorted(slices.Values(currentScopes))
+	if !slices.Equal(previous, current) {
+		logger.Warn("rotated token has different scopes; restart the server to update the available tools", "previousScopes", previous, "currentScopes", current)
+		return
+	}
+	logger.Debug("rotated token has the same scopes")
+}
 
-		if cfg.EnableCommandLogging {
-			loggedIO := mcplog.NewIOLogger(in, out, logger)
-			in, out = loggedIO, loggedIO
-		}
+// newAuditLog opens the audit log, or returns nil if audit logging is disabled.
+func newAuditLog(cfg StdioServerConfig) (*audit.Logger, error) {
+	if cfg.AuditLogPath == "" {
+		return nil, nil
+	}
+	file, err := audit.OpenRotatingFile(cfg.AuditLogPath, cfg.AuditLogMaxSize, cfg.AuditLogMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return audit.New(file), nil
+}
 
-		// enable GitHub errors in the context
-		ctx := errors.ContextWithGitHubErrors(ctx)
-		errC <- ghServer.Run(ctx, &mcp.IOTransport{Reader: in, Writer: out})
-	}()
+// newLimiter creates the limiter for tool calls, or returns nil if no limits are configured.
+func newLimiter(cfg StdioServerConfig) *limits.Limiter {
+	if !cfg.ToolLimits.Enabled() {
+		return nil
+	}
+	return limits.New(cfg.ToolLimits)
+}
 
-	// Output github-mcp-server string
-	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
+// newCassetteTransport creates the transport recording GitHub API traffic to a cassette or
+// replaying it from one, or returns nil if neither is configured.
+func newCassetteTransport(cfg StdioServerConfig, logger *slog.Logger) (http.RoundTripper, error) {
+	switch {
+	case cfg.RecordCassette != "" && cfg.ReplayCassette != "":
+		return nil, fmt.Errorf("cannot record and replay a cassette at the same time")
+	case cfg.RecordCassette != "":
+		logger.Info("recording GitHub API traffic", "cassette", cfg.RecordCassette)
+		return cassette.NewRecorder(cfg.RecordCassette, nil)
+	case cfg.ReplayCassette != "":
+		logger.Info("replaying GitHub API traffic", "cassette", cfg.ReplayCassette)
+		return cassette.NewReplayer(cfg.ReplayCassette)
+	default:
+		return nil, nil
+	}
+}
 
-	// Wait for shutdown signal
-	select {
-	case <-ctx.Done():
-		logger.Info("shutting down server", "signal", "context done")
-	case err := <-errC:
+// newResponseCache creates the store for cached GitHub API responses, or returns nil if caching is disabled.
+func newResponseCache(cfg StdioServerConfig) (httpcache.Store, error) {
+	if cfg.ResponseCacheSize <= 0 {
+		if cfg.ResponseCacheDir != "" {
+			return nil, fmt.Errorf("response cache directory %s is set but the response cache is disabled; set a response cache size to enable it", cfg.ResponseCacheDir)
+		}
+		return nil, nil
+	}
+	if cfg.RecordCassette != "" || cfg.ReplayCassette != "" {
+		// Cassettes hold complete responses, not revalidations against whatever happened to be cached
+		return nil, nil
+	}
+	if cfg.ResponseCacheDir != "" {
+		store, err := httpcache.NewDiskStore(cfg.ResponseCacheDir, cfg.ResponseCacheSize)
 		if err != nil {
-			logger.Error("error running server", "error", err)
-			return fmt.Errorf("error running server: %w", err)
+			return nil, fmt.Errorf("failed to open response cache: %w", err)
 		}
+		return store, nil
 	}
+	return httpcache.NewMemoryStore(cfg.ResponseCacheSize), nil
+}
 
-	return nil
+// newAppTransport creates the transport for the GitHub App API requests that exchange App
+// JWTs for installation tokens. Like the requests of tools, they go through the cassette
+// transport, if any, and are counted and traced.
+func newAppTransport(baseTransport http.RoundTripper, apiHost apiHost, serverMetrics *metrics.Metrics) http.RoundTripper {
+	transport := baseTransport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if serverMetrics != nil {
+		transport = &metrics.Transport{
+			Base:    transport,
+			Metrics: serverMetrics,
+			RawURL:  apiHost.rawURL,
+		}
+	}
+	return &tracing.Transport{
+		Base:   transport,
+		RawURL: apiHost.rawURL,
+	}
+}
+
+// newAppTokenSource creates a token source for a GitHub App installation on the given host,
+// sending the App API requests through transport.
+func newAppTokenSource(apiHost apiHost, cfg GitHubAppConfig, transport http.RoundTripper, version string) (*tokensource.AppInstallation, error) {
+	privateKey, err := os.ReadFile(cfg.PrivateKeyPath) //nolint:gosec // the path is configured by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+
+	return tokensource.NewAppInstallation(tokensource.AppOptions{
+		AppID:          cfg.AppID,
+		PrivateKey:     privateKey,
+		InstallationID: cfg.InstallationID,
+		Owner:          cfg.InstallationOwner,
+		BaseURL:        apiHost.baseRESTURL,
+		HTTPClient:     &http.Client{Transport: transport},
+		UserAgent:      fmt.Sprintf("github-mcp-server/%s", version),
+	})
+}
+
+// EndpointOverrides replaces individual GitHub API URLs that are otherwise derived from
+// the host, for example to route requests through a proxy. Empty fields keep the derived URL.
+type EndpointOverrides struct {
+	// RESTURL is the base URL of the REST API (e.g. https://api.github.com/)
+	RESTURL string
+
+	// GraphQLURL is the URL of the GraphQL endpoint (e.g. https://api.github.com/graphql)
+	GraphQLURL string
+
+	// UploadURL is the base URL for release asset uploads (e.g. https://uploads.github.com/)
+	UploadURL string
+
+	// RawURL is the base URL for raw file content (e.g. https://raw.githubusercontent.com/)
+	RawURL string
 }
 
 type apiHost struct {
@@ -502,33 +1048,38 @@ func newGHECHost(hostname string) (apiHost, error) {
 	}, nil
 }
 
-func newGHESHost(hostname string) (apiHost, error) {
+func newGHESHost(hostname string, overrides EndpointOverrides) (apiHost, error) {
 	u, err := url.Parse(hostname)
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES URL: %w", err)
 	}
 
-	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
 	}
 
-	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
 	}
 
 	// Check if subdomain isolation is enabled
 	// See https://docs.github.com/en/enterprise-server@3.17/admin/configuring-settings/hardening-security-for-your-enterprise/enabling-subdomain-isolation#about-subdomain-isolation
-	hasSubdomainIsolation := checkSubdomainIsolation(u.Scheme, u.Hostname())
+	// The probe asks the raw subdomain, so it is skipped when the raw URL is overridden, as the
+	// subdomain may then not exist. The upload URL is then on the host unless overridden too.
+	hasSubdomainIsolation := false
+	if overrides.RawURL == "" {
+		hasSubdomainIsolation = checkSubdomainIsolation(u.Scheme, u.Host)
+	}
 
 	var uploadURL *url.URL
 	if hasSubdomainIsolation {
 		// With subdomain isolation: https://uploads.hostname/
-		uploadURL, err = url.Parse(fmt.Sprintf("%s://uploads.%s/", u.Scheme, u.Hostname()))
+		uploadURL, err = url.Parse(fmt.Sprintf("%s://uploads.%s/", u.Scheme, u.Host))
 	} else {
 		// Without subdomain isolation: https://hostname/api/uploads/
-		uploadURL, err = url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+		uploadURL, err = url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Host))
 	}
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
@@ -537,10 +1088,10 @@ func newGHESHost(hostname string) (apiHost, error) {
 	var rawURL *url.URL
 	if hasSubdomainIsolation {
 		// With subdomain isolation: https://raw.hostname/
-		rawURL, err = url.Parse(fmt.Sprintf("%s://raw.%s/", u.Scheme, u.Hostname()))
+		rawURL, err = url.Parse(fmt.Sprintf("%s://raw.%s/", u.Scheme, u.Host))
 	} else {
 		// Without subdomain isolation: https://hostname/raw/
-		rawURL, err = url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Hostname()))
+		rawURL, err = url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Host))
 	}
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Raw URL: %w", err)
@@ -556,8 +1107,9 @@ func newGHESHost(hostname string) (apiHost, error) {
 
 // checkSubdomainIsolation detects if GitHub Enterprise Server has subdomain isolation enabled
 // by attempting to ping the raw.<host>/_ping endpoint on the subdomain. The raw subdomain must always exist for subdomain isolation.
-func checkSubdomainIsolation(scheme, hostname string) bool {
-	subdomainURL := fmt.Sprintf("%s://raw.%s/_ping", scheme, hostname)
+// The host may include a port.
+func checkSubdomainIsolation(scheme, host string) bool {
+	subdomainURL := fmt.Sprintf("%s://raw.%s/_ping", scheme, host)
 
 	client := &http.Client{
 		Timeout: 5 * time.Second,
@@ -577,8 +1129,17 @@ func checkSubdomainIsolation(scheme, hostname string) bool {
 	return resp.StatusCode == http.StatusOK
 }
 
-// Note that this does not handle ports yet, so development environments are out.
-func parseAPIHost(s string) (apiHost, error) {
+// parseAPIHost derives the REST, GraphQL, upload and raw URLs from the configured host,
+// then replaces any of them that are explicitly overridden. GHES hosts may include a port.
+func parseAPIHost(s string, overrides EndpointOverrides) (apiHost, error) {
+	host, err := parseDefaultAPIHost(s, overrides)
+	if err != nil {
+		return apiHost{}, err
+	}
+	return host.withOverrides(overrides)
+}
+
+func parseDefaultAPIHost(s string, overrides EndpointOverrides) (apiHost, error) {
 	if s == "" {
 		return newDotcomHost()
 	}
@@ -600,28 +1161,54 @@ func parseAPIHost(s string) (apiHost, error) {
 		return newGHECHost(s)
 	}
 
-	return newGHESHost(s)
+	return newGHESHost(s, overrides)
 }
 
-type userAgentTransport struct {
-	transport http.RoundTripper
-	agent     string
+// withOverrides returns the host with each URL set in overrides replacing the derived one.
+func (h apiHost) withOverrides(overrides EndpointOverrides) (apiHost, error) {
+	for _, override := range []struct {
+		name  string
+		value string
+		// go-github requires a trailing slash on the REST and upload base URLs
+		trailingSlash bool
+		target        **url.URL
+	}{
+		{"REST", overrides.RESTURL, true, &h.baseRESTURL},
+		{"GraphQL", overrides.GraphQLURL, false, &h.graphqlURL},
+		{"upload", overrides.UploadURL, true, &h.uploadURL},
+		{"raw", overrides.RawURL, true, &h.rawURL},
+	} {
+		if override.value == "" {
+			continue
+		}
+		u, err := url.Parse(override.value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return apiHost{}, fmt.Errorf("%s URL must be an absolute URL with a scheme and host: %s", override.name, override.value)
+		}
+		if override.trailingSlash && !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		*override.target = u
+	}
+	return h, nil
 }
 
-func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req = req.Clone(req.Context())
-	req.Header.Set("User-Agent", t.agent)
-	return t.transport.RoundTrip(req)
-}
+type userAgentKey struct{}
 
-type bearerAuthTransport struct {
+// userAgentTransport sets the User-Agent header of requests made on behalf of a session to
+// the user agent that userAgentMiddleware put in their context. The clients are shared by
+// every session of an HTTP server, so the user agent is set per request, not on the clients.
+type userAgentTransport struct {
 	transport http.RoundTripper
-	token     string
 }
 
-func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	agent, ok := req.Context().Value(userAgentKey{}).(string)
+	if !ok {
+		return t.transport.RoundTrip(req)
+	}
 	req = req.Clone(req.Context())
-	req.Header.Set("Authorization", "Bearer "+t.token)
+	req.Header.Set("User-Agent", agent)
 	return t.transport.RoundTrip(req)
 }
 
@@ -634,48 +1221,64 @@ func addGitHubAPIErrorToContext(next mcp.MethodHandler) mcp.MethodHandler {
 	}
 }
 
-func addUserAgentsMiddleware(cfg MCPServerConfig, restClient *gogithub.Client, gqlHTTPClient *http.Client) func(next mcp.MethodHandler) mcp.MethodHandler {
+// invalidateResponseCacheMiddleware drops the cached responses of a repository after a
+// write tool succeeds on it, so that reads following a write do not revalidate against
+// a replica that has not seen the write yet.
+func invalidateResponseCacheMiddleware(store httpcache.Store, inv *inventory.Inventory) func(next mcp.MethodHandler) mcp.MethodHandler {
 	return func(next mcp.MethodHandler) mcp.MethodHandler {
-		return func(ctx context.Context, method string, request mcp.Request) (result mcp.Result, err error) {
-			if method != "initialize" {
-				return next(ctx, method, request)
+		return func(ctx context.Context, method string, request mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, request)
+			if err != nil || method != "tools/call" {
+				return result, err
 			}
 
-			initializeRequest, ok := request.(*mcp.InitializeRequest)
+			callRequest, ok := request.(*mcp.CallToolRequest)
 			if !ok {
-				return next(ctx, method, request)
+				return result, err
+			}
+			if callResult, ok := result.(*mcp.CallToolResult); !ok || callResult.IsError {
+				return result, err
+			}
+			tool, _, findErr := inv.FindToolByName(callRequest.Params.Name)
+			if findErr != nil || tool.IsReadOnly() {
+				return result, err
 			}
 
-			message := initializeRequest
-			userAgent := fmt.Sprintf(
-				"github-mcp-server/%s (%s/%s)",
-				cfg.Version,
-				message.Params.ClientInfo.Name,
-				message.Params.ClientInfo.Version,
-			)
-
-			restClient.UserAgent = userAgent
-
-			gqlHTTPClient.Transport = &userAgentTransport{
-				transport: gqlHTTPClient.Transport,
-				agent:     userAgent,
+			var args struct {
+				Owner string `json:"owner"`
+				Repo  string `json:"repo"`
+			}
+			if json.Unmarshal(callRequest.Params.Arguments, &args) == nil && args.Owner != "" && args.Repo != "" {
+				store.InvalidateRepo(args.Owner, args.Repo)
 			}
+			return result, err
+		}
+	}
+}
 
+// userAgentMiddleware puts the user agent identifying the server and the MCP client of the
+// session in the context of every request, for userAgentTransport to send.
+func userAgentMiddleware(version string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, request mcp.Request) (mcp.Result, error) {
+			if session, ok := request.GetSession().(*mcp.ServerSession); ok {
+				if params := session.InitializeParams(); params != nil && params.ClientInfo != nil {
+					ctx = context.WithValue(ctx, userAgentKey{}, userAgent(version, params.ClientInfo))
+				}
+			}
 			return next(ctx, method, request)
 		}
 	}
 }
 
-// fetchTokenScopesForHost fetches the OAuth scopes for a token from the GitHub API.
-// It constructs the appropriate API host URL based on the configured host.
-func fetchTokenScopesForHost(ctx context.Context, token, host string) ([]string, error) {
-	apiHost, err := parseAPIHost(host)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
-	}
+func userAgent(version string, clientInfo *mcp.Implementation) string {
+	return fmt.Sprintf("github-mcp-server/%s (%s/%s)", version, clientInfo.Name, clientInfo.Version)
+}
 
+// fetchTokenScopesForHost fetches the OAuth scopes for a token from the REST API of the host.
+func fetchTokenScopesForHost(ctx context.Context, token string, host apiHost) ([]string, error) {
 	fetcher := scopes.NewFetcher(scopes.FetcherOptions{
-		APIHost: apiHost.baseRESTURL.String(),
+		APIHost: host.baseRESTURL.String(),
 	})
 
 	return fetcher.FetchTokenScopes(ctx, token)
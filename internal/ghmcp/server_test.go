@@ -1,9 +1,25 @@
 package ghmcp
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/cassette"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/httpcache"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -110,3 +126,273 @@ func TestResolveEnabledToolsets(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAPIHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		host              string
+		overrides         EndpointOverrides
+		expectedREST      string
+		expectedGraphQL   string
+		expectedUpload    string
+		expectedRaw       string
+		expectedErrString string
+	}{
+		{
+			name:            "dotcom by default",
+			expectedREST:    "https://api.github.com/",
+			expectedGraphQL: "https://api.github.com/graphql",
+			expectedUpload:  "https://uploads.github.com",
+			expectedRaw:     "https://raw.githubusercontent.com/",
+		},
+		{
+			name:            "GHEC",
+			host:            "https://octocorp.ghe.com",
+			expectedREST:    "https://api.octocorp.ghe.com/",
+			expectedGraphQL: "https://api.octocorp.ghe.com/graphql",
+			expectedUpload:  "https://uploads.octocorp.ghe.com",
+			expectedRaw:     "https://raw.octocorp.ghe.com/",
+		},
+		{
+			name: "GHES with port keeps the port",
+			host: "https://ghes.example.invalid:8443",
+			// The raw URL is overridden, so no probe is made
+			overrides: EndpointOverrides{
+				UploadURL: "https://uploads.example.invalid:8443/",
+				RawURL:    "https://raw.example.invalid:8443",
+			},
+			expectedREST:    "https://ghes.example.invalid:8443/api/v3/",
+			expectedGraphQL: "https://ghes.example.invalid:8443/api/graphql",
+			expectedUpload:  "https://uploads.example.invalid:8443/",
+			expectedRaw:     "https://raw.example.invalid:8443/",
+		},
+		{
+			name:            "GHES with raw override does not probe the raw subdomain",
+			host:            "https://ghes.example.invalid",
+			overrides:       EndpointOverrides{RawURL: "https://files.example.invalid/raw"},
+			expectedREST:    "https://ghes.example.invalid/api/v3/",
+			expectedGraphQL: "https://ghes.example.invalid/api/graphql",
+			expectedUpload:  "https://ghes.example.invalid/api/uploads/",
+			expectedRaw:     "https://files.example.invalid/raw/",
+		},
+		{
+			name: "overrides replace derived URLs",
+			overrides: EndpointOverrides{
+				RESTURL:    "http://localhost:3000/api",
+				GraphQLURL: "http://localhost:3000/graphql",
+			},
+			expectedREST:    "http://localhost:3000/api/",
+			expectedGraphQL: "http://localhost:3000/graphql",
+			expectedUpload:  "https://uploads.github.com",
+			expectedRaw:     "https://raw.githubusercontent.com/",
+		},
+		{
+			name:              "relative override is rejected",
+			overrides:         EndpointOverrides{RawURL: "/raw"},
+			expectedErrString: "raw URL must be an absolute URL",
+		},
+		{
+			name:              "host without scheme is rejected",
+			host:              "ghes.example.com",
+			expectedErrString: "host must have a scheme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host, err := parseAPIHost(tc.host, tc.overrides)
+			if tc.expectedErrString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrString)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedREST, host.baseRESTURL.String())
+			assert.Equal(t, tc.expectedGraphQL, host.graphqlURL.String())
+			assert.Equal(t, tc.expectedUpload, host.uploadURL.String())
+			assert.Equal(t, tc.expectedRaw, host.rawURL.String())
+		})
+	}
+}
+
+func TestParseAPIHost_GHESSubdomainIsolationWithPort(t *testing.T) {
+	t.Parallel()
+
+	// The probe goes to raw.<host:port>, which does not resolve for 127.0.0.1,
+	// so the server is treated as having no subdomain isolation.
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	host, err := parseAPIHost(ts.URL, EndpointOverrides{})
+	require.NoError(t, err)
+	assert.Equal(t, ts.URL+"/api/v3/", host.baseRESTURL.String())
+	assert.Equal(t, ts.URL+"/api/uploads/", host.uploadURL.String())
+	assert.Equal(t, ts.URL+"/raw/", host.rawURL.String())
+}
+
+// recordingStore is an httpcache.Store that records invalidated repositories.
+type recordingStore struct {
+	invalidated []string
+}
+
+func (s *recordingStore) Get(_ string) (*httpcache.Entry, bool) { return nil, false }
+func (s *recordingStore) Set(_ *httpcache.Entry)                {}
+func (s *recordingStore) InvalidateRepo(owner, repo string) {
+	s.invalidated = append(s.invalidated, owner+"/"+repo)
+}
+
+func TestNewResponseCache(t *testing.T) {
+	t.Parallel()
+
+	// The cache is off unless given a size
+	store, err := newResponseCache(StdioServerConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = newResponseCache(StdioServerConfig{ResponseCacheSize: 1 << 20})
+	require.NoError(t, err)
+	assert.NotNil(t, store)
+
+	_, err = newResponseCache(StdioServerConfig{ResponseCacheDir: t.TempDir()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set a response cache size")
+}
+
+func TestNewLocalMCPServer_GitHubAppReplaysCassette(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+
+	// The token exchange is served from the cassette, so starting the server needs no network
+	interaction, err := json.Marshal(cassette.Interaction{
+		Request: cassette.Request{Method: http.MethodPost, URL: "https://api.github.com/app/installations/7/access_tokens", Body: "null"},
+		Response: cassette.Response{
+			StatusCode: http.StatusCreated,
+			Body:       `{"token":"ghs_replayed","expires_at":"2099-01-01T00:00:00Z"}`,
+		},
+	})
+	require.NoError(t, err)
+	cassettePath := filepath.Join(t.TempDir(), "app.jsonl")
+	require.NoError(t, os.WriteFile(cassettePath, append(interaction, '\n'), 0o600))
+
+	cfg := StdioServerConfig{
+		Version:         "test",
+		GitHubApp:       &GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath, InstallationID: 7},
+		EnabledToolsets: []string{"context"},
+		ReplayCassette:  cassettePath,
+	}
+	server, err := newLocalMCPServer(context.Background(), cfg, translations.NullTranslationHelper, slog.New(slog.DiscardHandler), nil)
+	require.NoError(t, err)
+	assert.NotNil(t, server)
+}
+
+func TestInvalidateResponseCacheMiddleware(t *testing.T) {
+	t.Parallel()
+
+	inv := github.NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	tests := []struct {
+		name     string
+		tool     string
+		result   *mcp.CallToolResult
+		expected []string
+	}{
+		{
+			name:     "successful write tool invalidates the repository",
+			tool:     "issue_write",
+			result:   &mcp.CallToolResult{},
+			expected: []string{"owner/repo"},
+		},
+		{
+			name:   "failed write tool keeps the cache",
+			tool:   "issue_write",
+			result: &mcp.CallToolResult{IsError: true},
+		},
+		{
+			name:   "read tool keeps the cache",
+			tool:   "issue_read",
+			result: &mcp.CallToolResult{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &recordingStore{}
+			handler := invalidateResponseCacheMiddleware(store, inv)(func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				return tc.result, nil
+			})
+
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+				Name:      tc.tool,
+				Arguments: json.RawMessage(`{"owner": "owner", "repo": "repo", "title": "t"}`),
+			}}
+			_, err := handler(context.Background(), "tools/call", req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, store.invalidated)
+		})
+	}
+}
+
+// userAgentRecorder is an http.RoundTripper that records the User-Agent header of every
+// request and answers it with an empty JSON object.
+type userAgentRecorder struct {
+	mu     sync.Mutex
+	agents []string
+}
+
+func (r *userAgentRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.agents = append(r.agents, req.Header.Get("User-Agent"))
+	r.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+func TestNewMCPServer_UserAgentPerSession(t *testing.T) {
+	t.Parallel()
+
+	recorder := &userAgentRecorder{}
+	server, err := NewMCPServer(MCPServerConfig{
+		Version:           "test",
+		Token:             "test-token",
+		EnabledToolsets:   []string{"context"},
+		Translator:        translations.NullTranslationHelper,
+		ContentWindowSize: 5000,
+		BaseTransport:     recorder,
+	})
+	require.NoError(t, err)
+
+	// Sessions of an HTTP server share the server and its clients
+	ts := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server { return server }, nil))
+	defer ts.Close()
+
+	ctx := context.Background()
+	var sessions []*mcp.ClientSession
+	for _, name := range []string{"first-client", "second-client"} {
+		client := mcp.NewClient(&mcp.Implementation{Name: name, Version: "1.0"}, nil)
+		session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: ts.URL}, nil)
+		require.NoError(t, err)
+		defer session.Close()
+		sessions = append(sessions, session)
+	}
+
+	// Call from the first session after the second one connected, which used to take over the user agent
+	for _, session := range sessions {
+		_, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_me", Arguments: map[string]any{}})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{
+		"github-mcp-server/test (first-client/1.0)",
+		"github-mcp-server/test (second-client/1.0)",
+	}, recorder.agents)
+}
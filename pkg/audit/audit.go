@@ -0,0 +1,227 @@
+// Package audit records the write operations tools perform on GitHub as JSON lines, so that
+// what agents changed can be reviewed later.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Statuses of a tool invocation, reported in Entry.Status.
+const (
+	StatusSuccess   = "success"
+	StatusToolError = "tool_error"
+	StatusError     = "error"
+)
+
+// maxArgumentLength is the longest string argument recorded in full. Longer strings, such as
+// file contents and issue bodies, are cut short so that the log stays reviewable.
+const maxArgumentLength = 256
+
+// argumentRedaction is how arguments and error messages are cleaned before they are recorded.
+var argumentRedaction = mcplog.Redaction{MaxStringLength: maxArgumentLength}
+
+// Entry is one line of the audit log, describing a single invocation of a write tool.
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	SessionID  string         `json:"session_id,omitempty"`
+	Tool       string         `json:"tool"`
+	Owner      string         `json:"owner,omitempty"`
+	Repo       string         `json:"repo,omitempty"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	DryRun     bool           `json:"dry_run,omitempty"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	URLs       []string       `json:"urls,omitempty"`
+	RequestIDs []string       `json:"request_ids,omitempty"`
+}
+
+// Logger writes audit entries as JSON lines. It is safe for concurrent use.
+type Logger struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// New creates a Logger that writes to w.
+func New(w io.Writer) *Logger {
+	return &Logger{w: w, now: time.Now}
+}
+
+// Log writes a single entry, setting its time if unset.
+func (l *Logger) Log(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = l.now().UTC()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(line)
+	return err
+}
+
+// Middleware returns receiving middleware for an mcp.Server that logs every call of a tool
+// in inv that is not read-only. GitHub request IDs are recorded for clients using Transport.
+// Calls run as dry runs by dryrun.Middleware, added after this middleware, are marked as such.
+// Failures to write the log are reported to the server logger and do not fail the call.
+func (l *Logger) Middleware(inv *inventory.Inventory, logger *slog.Logger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callRequest.Params == nil {
+				return next(ctx, method, req)
+			}
+			tool, _, err := inv.FindToolByName(callRequest.Params.Name)
+			if err != nil || tool.IsReadOnly() {
+				return next(ctx, method, req)
+			}
+
+			rec := &recorder{}
+			result, err := next(context.WithValue(ctx, recorderKey{}, rec), method, req)
+
+			entry := Entry{
+				Tool:       callRequest.Params.Name,
+				Arguments:  SanitizeArguments(callRequest.Params.Arguments),
+				DryRun:     dryrun.Enabled(ctx),
+				RequestIDs: rec.requestIDs(),
+			}
+			if session, ok := req.GetSession().(*mcp.ServerSession); ok && session != nil {
+				entry.SessionID = session.ID()
+			}
+			entry.Owner, _ = entry.Arguments["owner"].(string)
+			entry.Repo, _ = entry.Arguments["repo"].(string)
+
+			callResult, _ := result.(*mcp.CallToolResult)
+			switch {
+			case err != nil:
+				entry.Status = StatusError
+				entry.Error = err.Error()
+			case callResult != nil && callResult.IsError:
+				entry.Status = StatusToolError
+				entry.Error = resultText(callResult)
+			default:
+				entry.Status = StatusSuccess
+				entry.URLs = resultURLs(callResult)
+			}
+
+			if logErr := l.Log(entry); logErr != nil && logger != nil {
+				logger.Error("failed to write audit log", "tool", entry.Tool, "error", logErr)
+			}
+			return result, err
+		}
+	}
+}
+
+// SanitizeArguments decodes the arguments of a tool call for the audit log. Values of
+// arguments named like credentials and GitHub tokens inside strings are redacted, and long
+// strings are truncated.
+func SanitizeArguments(raw json.RawMessage) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var args map[string]any
+	if err := json.Unmarshal(argumentRedaction.Redact(raw), &args); err != nil {
+		return map[string]any{"_raw": argumentRedaction.RedactString(string(raw))}
+	}
+	return args
+}
+
+// resultText joins the text content of a result, which holds the message of tool errors.
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return argumentRedaction.RedactString(strings.Join(parts, "\n"))
+}
+
+// resultURLs returns the URLs of the objects a tool created or changed, taken from the url
+// and html_url fields of JSON text results.
+func resultURLs(result *mcp.CallToolResult) []string {
+	if result == nil {
+		return nil
+	}
+	var urls []string
+	for _, content := range result.Content {
+		text, ok := content.(*mcp.TextContent)
+		if !ok {
+			continue
+		}
+		var fields struct {
+			URL     string `json:"url"`
+			HTMLURL string `json:"html_url"`
+		}
+		if json.Unmarshal([]byte(text.Text), &fields) != nil {
+			continue
+		}
+		for _, u := range []string{fields.HTMLURL, fields.URL} {
+			if u != "" {
+				urls = append(urls, u)
+			}
+		}
+	}
+	return urls
+}
+
+type recorderKey struct{}
+
+// recorder collects the GitHub request IDs of the requests made during a tool call.
+type recorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *recorder) add(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, id)
+}
+
+func (r *recorder) requestIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ids
+}
+
+// Transport is an http.RoundTripper that records the X-GitHub-Request-Id of every response
+// to a request made during an audited tool call.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if rec, ok := req.Context().Value(recorderKey{}).(*recorder); ok {
+		if id := resp.Header.Get("X-GitHub-Request-Id"); id != "" {
+			rec.add(id)
+		}
+	}
+	return resp, nil
+}
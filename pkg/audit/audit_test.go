@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTool(name string, readOnly bool) inventory.ServerTool {
+	return inventory.NewServerToolFromHandler(
+		mcp.Tool{
+			Name:        name,
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		inventory.ToolsetMetadata{ID: "test"},
+		func(_ any) mcp.ToolHandler {
+			return func(_ context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return nil, nil
+			}
+		},
+	)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	// The GitHub API stub returns a request ID, which Transport records for the audited call
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	inv := inventory.NewBuilder().
+		SetTools([]inventory.ServerTool{testTool("create_issue", false), testTool("get_issue", true)}).
+		Build()
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     string
+		result   *mcp.CallToolResult
+		err      error
+		expected *Entry
+	}{
+		{
+			name: "successful write",
+			tool: "create_issue",
+			args: `{"owner":"octo","repo":"hello","title":"Bug","body":"` + strings.Repeat("x", 300) + `","github_token":"ghp_secret"}`,
+			result: &mcp.CallToolResult{Content: []mcp.Content{
+				&mcp.TextContent{Text: `{"id":"1","url":"https://github.com/octo/hello/issues/1"}`},
+			}},
+			expected: &Entry{
+				Tool:  "create_issue",
+				Owner: "octo",
+				Repo:  "hello",
+				Arguments: map[string]any{
+					"owner":        "octo",
+					"repo":         "hello",
+					"title":        "Bug",
+					"body":         strings.Repeat("x", 256) + "…[truncated, 300 bytes]",
+					"github_token": "[REDACTED]",
+				},
+				Status:     StatusSuccess,
+				URLs:       []string{"https://github.com/octo/hello/issues/1"},
+				RequestIDs: []string{"ABCD:1234"},
+			},
+		},
+		{
+			name:   "write returning an error result",
+			tool:   "create_issue",
+			args:   `{"owner":"octo","repo":"hello"}`,
+			result: &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "missing required parameter: title"}}},
+			expected: &Entry{
+				Tool:       "create_issue",
+				Owner:      "octo",
+				Repo:       "hello",
+				Arguments:  map[string]any{"owner": "octo", "repo": "hello"},
+				Status:     StatusToolError,
+				Error:      "missing required parameter: title",
+				RequestIDs: []string{"ABCD:1234"},
+			},
+		},
+		{
+			name: "failed write",
+			tool: "create_issue",
+			err:  errors.New("boom"),
+			expected: &Entry{
+				Tool:       "create_issue",
+				Status:     StatusError,
+				Error:      "boom",
+				RequestIDs: []string{"ABCD:1234"},
+			},
+		},
+		{
+			name:   "read-only tool is not logged",
+			tool:   "get_issue",
+			args:   `{"owner":"octo","repo":"hello"}`,
+			result: &mcp.CallToolResult{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(&buf)
+			logger.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+			handler := logger.Middleware(inv, nil)(func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL, nil)
+				require.NoError(t, err)
+				resp, err := client.Do(req)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				return tc.result, tc.err
+			})
+
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tc.tool, Arguments: json.RawMessage(tc.args)}}
+			_, err := handler(context.Background(), "tools/call", req)
+			assert.Equal(t, tc.err, err)
+
+			if tc.expected == nil {
+				assert.Empty(t, buf.String())
+				return
+			}
+			tc.expected.Time = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+			var entry Entry
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, *tc.expected, entry)
+			assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+		})
+	}
+}
+
+func TestMiddleware_DryRun(t *testing.T) {
+	t.Parallel()
+
+	inv := inventory.NewBuilder().SetTools([]inventory.ServerTool{testTool("create_issue", false)}).Build()
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	// Dry runs are started outside the audit log, which sees the call without the dry_run argument
+	handler := dryrun.Middleware(inv, false)(logger.Middleware(inv, nil)(func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	}))
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "create_issue", Arguments: json.RawMessage(`{"owner":"octo","dry_run":true}`)}}
+	_, err := handler(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.True(t, entry.DryRun)
+	assert.Equal(t, map[string]any{"owner": "octo"}, entry.Arguments)
+}
+
+func TestSanitizeArguments(t *testing.T) {
+	t.Parallel()
+
+	token := "ghp_" + strings.Repeat("a", 36)
+	args := SanitizeArguments(json.RawMessage(`{"body":"my token is ` + token + `","private_key":"x"}`))
+	assert.Equal(t, map[string]any{"body": "my token is [REDACTED]", "private_key": "[REDACTED]"}, args)
+
+	args = SanitizeArguments(json.RawMessage(`["` + token + `"]`))
+	assert.Equal(t, map[string]any{"_raw": `["[REDACTED]"]`}, args)
+
+	assert.Nil(t, SanitizeArguments(nil))
+}
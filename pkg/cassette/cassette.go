@@ -0,0 +1,153 @@
+// Package cassette records GitHub API traffic to cassette files and replays it offline, for
+// demos, bug reports and regression tests.
+//
+// A cassette is a JSON Lines file with one Interaction per line, in the order the requests
+// were made. Credentials are stripped from recorded requests, and GitHub tokens from recorded
+// response bodies.
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// strippedHeaders are removed from recorded requests and responses so that cassettes can be shared.
+var strippedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-GitHub-Otp"}
+
+// base64Encoding marks bodies that are not valid UTF-8 and are stored base64 encoded.
+const base64Encoding = "base64"
+
+// Interaction is a recorded request and the response it got.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Load reads all interactions from a cassette file.
+func Load(path string) ([]Interaction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var interactions []Interaction
+	scanner := bufio.NewScanner(f)
+	// Responses such as file contents can be far longer than the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s line %d: %w", path, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	return interactions, nil
+}
+
+// MatchKey returns the key recorded and replayed requests are matched on: the method, the
+// URL with its query parameters sorted and the normalized body. GraphQL bodies are
+// normalized the way internal/githubv4mock compares them, by query text and variable values,
+// with insignificant whitespace in the query and the order of keys ignored.
+func MatchKey(method string, u *url.URL, body []byte) string {
+	normalized := *u
+	normalized.RawQuery = u.Query().Encode()
+	normalized.Fragment = ""
+	return method + " " + normalized.String() + "\n" + normalizeBody(u, body)
+}
+
+func normalizeBody(u *url.URL, body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	if strings.HasSuffix(u.Path, "/graphql") {
+		var gql struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables,omitempty"`
+		}
+		if json.Unmarshal(body, &gql) == nil {
+			gql.Query = strings.Join(strings.Fields(gql.Query), " ")
+			if normalized, err := json.Marshal(gql); err == nil {
+				return string(normalized)
+			}
+		}
+	}
+	// Re-encoding JSON sorts object keys
+	var value any
+	if json.Unmarshal(body, &value) == nil {
+		if normalized, err := json.Marshal(value); err == nil {
+			return string(normalized)
+		}
+	}
+	return string(body)
+}
+
+// encodeBody stores body as text, or base64 encoded if it is binary.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), base64Encoding
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == base64Encoding {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
+
+// stripHeaders returns a copy of h without credentials.
+func stripHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range strippedHeaders {
+		h.Del(name)
+	}
+	return h
+}
+
+// readBody reads and replaces *body so that it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,149 @@
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		switch r.URL.Path {
+		case "/graphql":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = fmt.Fprintf(w, `{"data":{"echo":%q}}`, body)
+		case "/raw/image.png":
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G', 0xff})
+		case "/app/installations/1/access_tokens":
+			_, _ = fmt.Fprint(w, `{"token":"ghs_`+strings.Repeat("a", 36)+`"}`)
+		default:
+			_, _ = fmt.Fprintf(w, `{"call":%d}`, n)
+		}
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := NewRecorder(path, nil)
+	require.NoError(t, err)
+
+	do := func(client *http.Client, method, target, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+target, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer ghp_secret")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	recording := &http.Client{Transport: recorder}
+	_, first := do(recording, http.MethodGet, "/repos/o/r/issues?state=open&page=1", "")
+	_, second := do(recording, http.MethodGet, "/repos/o/r/issues?state=open&page=1", "")
+	_, gql := do(recording, http.MethodPost, "/graphql", `{"query":"query { viewer { login } }","variables":{"a":1,"b":"x"}}`)
+	_, image := do(recording, http.MethodGet, "/raw/image.png", "")
+	// The caller gets the token, but the cassette does not
+	_, installationToken := do(recording, http.MethodPost, "/app/installations/1/access_tokens", "")
+	assert.Contains(t, installationToken, "ghs_")
+	require.NoError(t, recorder.Close())
+	require.Equal(t, int32(5), calls.Load())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "ghp_secret")
+	assert.NotContains(t, string(data), "session=secret")
+	assert.NotContains(t, string(data), "ghs_")
+	assert.Contains(t, string(data), "ABCD:1234")
+
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+	replaying := &http.Client{Transport: replayer}
+
+	// Requests with the same key get the recorded responses in order, then the last one again
+	status, body := do(replaying, http.MethodGet, "/repos/o/r/issues?page=1&state=open", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, first, body)
+	_, body = do(replaying, http.MethodGet, "/repos/o/r/issues?state=open&page=1", "")
+	assert.Equal(t, second, body)
+	_, body = do(replaying, http.MethodGet, "/repos/o/r/issues?state=open&page=1", "")
+	assert.Equal(t, second, body)
+
+	// GraphQL requests match regardless of whitespace in the query and the order of variables
+	_, body = do(replaying, http.MethodPost, "/graphql", `{"variables":{"b":"x","a":1},"query":"query {\n  viewer {\n    login\n  }\n}"}`)
+	assert.Equal(t, gql, body)
+
+	_, body = do(replaying, http.MethodGet, "/raw/image.png", "")
+	assert.Equal(t, image, body)
+
+	_, body = do(replaying, http.MethodPost, "/app/installations/1/access_tokens", "")
+	assert.JSONEq(t, `{"token":"[REDACTED]"}`, body)
+
+	// Nothing reaches the server while replaying
+	assert.Equal(t, int32(5), calls.Load())
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/repos/o/r/pulls", nil)
+	require.NoError(t, err)
+	_, err = replaying.Do(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cassette has no recorded response for GET")
+}
+
+func TestMatchKey(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("https://api.github.com/graphql")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{
+			name:  "GraphQL whitespace and key order",
+			a:     `{"query":"query($n:Int!){ a(n:$n) }","variables":{"n":1,"m":"x"}}`,
+			b:     `{"variables":{"m":"x","n":1.0},"query":"query($n:Int!){\n\ta(n:$n)\n}"}`,
+			equal: true,
+		},
+		{
+			name: "GraphQL variables differ",
+			a:    `{"query":"query { a }","variables":{"n":1}}`,
+			b:    `{"query":"query { a }","variables":{"n":2}}`,
+		},
+		{
+			name: "GraphQL queries differ",
+			a:    `{"query":"query { a }"}`,
+			b:    `{"query":"query { b }"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := MatchKey(http.MethodPost, u, []byte(tc.a))
+			b := MatchKey(http.MethodPost, u, []byte(tc.b))
+			if tc.equal {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a, b)
+			}
+		})
+	}
+}
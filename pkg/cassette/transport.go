@@ -0,0 +1,190 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+)
+
+// tokenRedaction removes GitHub tokens from recorded response bodies.
+var tokenRedaction = mcplog.Redaction{}
+
+// Recorder is an http.RoundTripper that appends every request and its response to a cassette.
+type Recorder struct {
+	base http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder creates a Recorder appending to the cassette at path, sending requests through
+// base. http.DefaultTransport is used if base is nil.
+func NewRecorder(path string, base http.RoundTripper) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{base: base, file: file}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body for cassette: %w", err)
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for cassette: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: stripHeaders(req.Header),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     stripHeaders(resp.Header),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+	if interaction.Response.BodyEncoding == "" {
+		// Responses can carry tokens, such as GitHub App installation tokens
+		interaction.Response.Body = tokenRedaction.RedactString(interaction.Response.Body)
+	}
+	if resp.Uncompressed {
+		// The body was decompressed by the transport, so the encoding headers no longer apply
+		interaction.Response.Header.Del("Content-Encoding")
+		interaction.Response.Header.Del("Content-Length")
+	}
+
+	if err := r.write(interaction); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) write(interaction Interaction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette interaction: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Close closes the cassette file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette and never sends
+// requests. Requests are matched by MatchKey. Requests with the same key get the recorded
+// responses in the order they were recorded, and the last one once they run out.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	served       map[string]int
+}
+
+// NewReplayer creates a Replayer serving the interactions in the cassette at path.
+func NewReplayer(path string) (*Replayer, error) {
+	interactions, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayerFromInteractions(interactions)
+}
+
+// NewReplayerFromInteractions creates a Replayer serving the given interactions.
+func NewReplayerFromInteractions(interactions []Interaction) (*Replayer, error) {
+	r := &Replayer{
+		interactions: make(map[string][]Interaction),
+		served:       make(map[string]int),
+	}
+	for i, interaction := range interactions {
+		req, err := http.NewRequest(interaction.Request.Method, interaction.Request.URL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request in cassette interaction %d: %w", i+1, err)
+		}
+		body, err := decodeBody(interaction.Request.Body, interaction.Request.BodyEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request body in cassette interaction %d: %w", i+1, err)
+		}
+		key := MatchKey(req.Method, req.URL, body)
+		r.interactions[key] = append(r.interactions[key], interaction)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Like any transport, the replayer consumes the request body
+	reqBody := req.Body
+	body, err := readBody(&reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body for cassette: %w", err)
+	}
+	key := MatchKey(req.Method, req.URL, body)
+
+	r.mu.Lock()
+	recorded := r.interactions[key]
+	if len(recorded) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("cassette has no recorded response for %s %s", req.Method, req.URL)
+	}
+	i := min(r.served[key], len(recorded)-1)
+	r.served[key]++
+	r.mu.Unlock()
+
+	recordedResponse := recorded[i].Response
+	respBody, err := decodeBody(recordedResponse.Body, recordedResponse.BodyEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response body in cassette: %w", err)
+	}
+	header := recordedResponse.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(respBody)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResponse.StatusCode, http.StatusText(recordedResponse.StatusCode)),
+		StatusCode:    recordedResponse.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,281 @@
+// Package confirm asks the user to confirm calls of chosen tools before they run, through
+// MCP elicitation, so that an agent cannot delete or merge anything without a person seeing
+// what it is about to do.
+package confirm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// KeywordDestructive stands for every tool marked destructive in a list of tools to confirm.
+const KeywordDestructive = "destructive"
+
+// Reasons reported in the structured content of refused tool calls.
+const (
+	// ReasonDeclined is reported when the user declines or cancels the confirmation.
+	ReasonDeclined = "confirmation_declined"
+	// ReasonUnavailable is reported when the client cannot ask the user, because it does not
+	// support elicitation or the request failed.
+	ReasonUnavailable = "confirmation_unavailable"
+)
+
+// entry is a parsed list entry.
+type entry struct {
+	// text is the entry as given, for messages
+	text string
+	// tool and method are glob patterns for the tool name and the value of its method
+	// argument; an empty method matches every call of the tool
+	tool, method string
+}
+
+func (e entry) matches(tool string, args map[string]any) bool {
+	if matched, _ := path.Match(e.tool, tool); !matched {
+		return false
+	}
+	if e.method == "" {
+		return true
+	}
+	method, _ := args["method"].(string)
+	matched, _ := path.Match(e.method, strings.ToLower(method))
+	return matched
+}
+
+// List is the set of tools whose calls must be confirmed. A nil List confirms nothing.
+type List struct {
+	destructive bool
+	entries     []entry
+}
+
+// Parse builds a List from entries naming tools, such as "delete_file", or glob patterns such
+// as "*_delete*". An entry may be limited to one method of a tool that takes a method argument
+// with "tool:method", such as "label_write:delete". The keyword "destructive" adds every tool
+// marked destructive. It returns nil if there are no entries.
+func Parse(entries []string) (*List, error) {
+	l := &List{}
+	for _, text := range entries {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if text == KeywordDestructive {
+			l.destructive = true
+			continue
+		}
+		tool, method, _ := strings.Cut(text, ":")
+		if tool == "" || strings.Contains(text, ":") && method == "" {
+			return nil, fmt.Errorf("invalid confirmation entry %q: expected tool or tool:method", text)
+		}
+		e := entry{text: text, tool: tool, method: strings.ToLower(method)}
+		for _, pattern := range []string{e.tool, e.method} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid confirmation entry %q: %w", text, err)
+			}
+		}
+		l.entries = append(l.entries, e)
+	}
+	if !l.destructive && len(l.entries) == 0 {
+		return nil, nil
+	}
+	return l, nil
+}
+
+// Validate returns an error if an entry matches none of tools, or names a method the tool
+// does not have, so that a misspelled entry does not leave a tool unconfirmed.
+func (l *List) Validate(tools []inventory.ServerTool) error {
+	if l == nil {
+		return nil
+	}
+	for _, e := range l.entries {
+		found := false
+		for i := range tools {
+			if matched, _ := path.Match(e.tool, tools[i].Tool.Name); !matched {
+				continue
+			}
+			methods, ok := toolMethods(&tools[i])
+			if e.method == "" || !ok {
+				found = true
+				break
+			}
+			for _, method := range methods {
+				if matched, _ := path.Match(e.method, method); matched {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid confirmation entry %q: no tool or method matches it", e.text)
+		}
+	}
+	return nil
+}
+
+// toolMethods returns the values the method argument of a tool may take, and false if the
+// tool does not list them.
+func toolMethods(tool *inventory.ServerTool) ([]string, bool) {
+	schema, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil || schema.Properties["method"] == nil {
+		return nil, false
+	}
+	var methods []string
+	for _, value := range schema.Properties["method"].Enum {
+		if method, ok := value.(string); ok {
+			methods = append(methods, strings.ToLower(method))
+		}
+	}
+	return methods, len(methods) > 0
+}
+
+// Requires reports whether a call of tool with args must be confirmed.
+func (l *List) Requires(tool *inventory.ServerTool, args map[string]any) bool {
+	if l == nil {
+		return false
+	}
+	if l.destructive && tool.IsDestructive() {
+		return true
+	}
+	for _, e := range l.entries {
+		if e.matches(tool.Tool.Name, args) {
+			return true
+		}
+	}
+	return false
+}
+
+// String lists the entries of the list.
+func (l *List) String() string {
+	if l == nil {
+		return "none"
+	}
+	var parts []string
+	if l.destructive {
+		parts = append(parts, KeywordDestructive)
+	}
+	for _, e := range l.entries {
+		parts = append(parts, e.text)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Middleware returns receiving middleware for an mcp.Server that asks the user to confirm the
+// calls of tools in inv that l requires, with a summary of the call, before running them.
+// Calls are refused with an error result if the user does not accept, or if the client does
+// not support elicitation. Dry runs, which change nothing, are not confirmed.
+func Middleware(l *List, inv *inventory.Inventory) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callRequest.Params == nil || dryrun.Enabled(ctx) {
+				return next(ctx, method, req)
+			}
+			tool, _, err := inv.FindToolByName(callRequest.Params.Name)
+			if err != nil {
+				return next(ctx, method, req)
+			}
+			var args map[string]any
+			if len(callRequest.Params.Arguments) > 0 {
+				_ = json.Unmarshal(callRequest.Params.Arguments, &args)
+			}
+			if !l.Requires(tool, args) {
+				return next(ctx, method, req)
+			}
+
+			if refusal := ask(ctx, callRequest.Session, tool, callRequest.Params.Arguments); refusal != nil {
+				return refusal.result(callRequest.Params.Name), nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// ask asks the user to confirm a call, returning the refusal if it is not confirmed.
+func ask(ctx context.Context, session *mcp.ServerSession, tool *inventory.ServerTool, arguments json.RawMessage) *refusal {
+	if session == nil || !supportsElicitation(session) {
+		return &refusal{
+			Reason:  ReasonUnavailable,
+			Message: "it must be confirmed by the user, but the client does not support elicitation to ask for confirmation",
+		}
+	}
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         Summary(tool, arguments),
+		RequestedSchema: &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}},
+	})
+	if err != nil {
+		return &refusal{
+			Reason:  ReasonUnavailable,
+			Message: fmt.Sprintf("it must be confirmed by the user, but asking for confirmation failed: %v", err),
+		}
+	}
+	if result.Action != "accept" {
+		return &refusal{
+			Reason:  ReasonDeclined,
+			Message: fmt.Sprintf("the user did not confirm it (%s)", result.Action),
+		}
+	}
+	return nil
+}
+
+func supportsElicitation(session *mcp.ServerSession) bool {
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// Summary describes a call for the user to confirm: the tool and the arguments it was called
+// with, sorted by name. Arguments are redacted and truncated as in the audit log.
+func Summary(tool *inventory.ServerTool, arguments json.RawMessage) string {
+	title := tool.Tool.Name
+	if tool.Tool.Annotations != nil && tool.Tool.Annotations.Title != "" {
+		title = fmt.Sprintf("%s (%s)", tool.Tool.Annotations.Title, tool.Tool.Name)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Allow the agent to run %s", title)
+	args := audit.SanitizeArguments(arguments)
+	if owner, _ := args["owner"].(string); owner != "" {
+		if repo, _ := args["repo"].(string); repo != "" {
+			fmt.Fprintf(&b, " on %s/%s", owner, repo)
+		}
+	}
+	b.WriteString("?")
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := args[name]
+		if text, ok := value.(string); ok {
+			fmt.Fprintf(&b, "\n- %s: %s", name, text)
+			continue
+		}
+		data, _ := json.Marshal(value)
+		fmt.Fprintf(&b, "\n- %s: %s", name, data)
+	}
+	return b.String()
+}
+
+// refusal is a call that was not confirmed. It is reported in the structured content of the
+// result of the refused tool call.
+type refusal struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func (r *refusal) result(tool string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s was not run: %s", tool, r.Message)}},
+		StructuredContent: r,
+		IsError:           true,
+	}
+}
@@ -0,0 +1,195 @@
+package confirm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTool(name string, destructive bool, methods ...any) inventory.ServerTool {
+	schema := &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}}
+	if len(methods) > 0 {
+		schema.Properties["method"] = &jsonschema.Schema{Type: "string", Enum: methods}
+	}
+	return inventory.NewServerToolFromHandler(
+		mcp.Tool{
+			Name:        name,
+			Annotations: &mcp.ToolAnnotations{Title: "Title of " + name, DestructiveHint: &destructive},
+			InputSchema: schema,
+		},
+		inventory.ToolsetMetadata{ID: "test"},
+		func(_ any) mcp.ToolHandler {
+			return func(_ context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil
+			}
+		},
+	)
+}
+
+func testTools() []inventory.ServerTool {
+	return []inventory.ServerTool{
+		testTool("delete_file", true),
+		testTool("merge_pull_request", false),
+		testTool("label_write", false, "create", "update", "delete"),
+		testTool("get_me", false),
+	}
+}
+
+func TestParse(t *testing.T) {
+	list, err := Parse(nil)
+	require.NoError(t, err)
+	assert.Nil(t, list)
+	assert.Equal(t, "none", list.String())
+
+	list, err = Parse([]string{" destructive ", "label_write:DELETE", "", "*_pull_request"})
+	require.NoError(t, err)
+	assert.Equal(t, "destructive, label_write:DELETE, *_pull_request", list.String())
+
+	for _, entry := range []string{":delete", "label_write:", "label_write:[", "[:delete"} {
+		_, err := Parse([]string{entry})
+		assert.Error(t, err, entry)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tools := testTools()
+	tests := []struct {
+		entry string
+		valid bool
+	}{
+		{entry: "delete_file", valid: true},
+		{entry: "*_pull_request", valid: true},
+		{entry: "label_write:delete", valid: true},
+		{entry: "label_write:del*", valid: true},
+		{entry: "delete_fiel", valid: false},
+		{entry: "label_write:remove", valid: false},
+		// Methods are not checked for tools that do not list them
+		{entry: "delete_file:anything", valid: true},
+	}
+	for _, tc := range tests {
+		list, err := Parse([]string{tc.entry})
+		require.NoError(t, err)
+		err = list.Validate(tools)
+		if tc.valid {
+			assert.NoError(t, err, tc.entry)
+		} else {
+			assert.ErrorContains(t, err, tc.entry)
+		}
+	}
+
+	var none *List
+	assert.NoError(t, none.Validate(tools))
+}
+
+func TestRequires(t *testing.T) {
+	tools := testTools()
+	list, err := Parse([]string{"destructive", "label_write:delete", "merge_*"})
+	require.NoError(t, err)
+
+	assert.True(t, list.Requires(&tools[0], nil))
+	assert.True(t, list.Requires(&tools[1], map[string]any{"pullNumber": 1}))
+	assert.True(t, list.Requires(&tools[2], map[string]any{"method": "Delete"}))
+	assert.False(t, list.Requires(&tools[2], map[string]any{"method": "create"}))
+	assert.False(t, list.Requires(&tools[2], nil))
+	assert.False(t, list.Requires(&tools[3], nil))
+
+	var none *List
+	assert.False(t, none.Requires(&tools[0], nil))
+}
+
+func TestSummary(t *testing.T) {
+	tool := testTool("delete_file", true)
+	summary := Summary(&tool, json.RawMessage(`{"owner":"octo","repo":"hello","path":"README.md","github_token":"secret","files":[{"path":"a"}]}`))
+	assert.Equal(t, "Allow the agent to run Title of delete_file (delete_file) on octo/hello?\n"+
+		"- files: [{\"path\":\"a\"}]\n"+
+		"- github_token: [REDACTED]\n"+
+		"- owner: octo\n"+
+		"- path: README.md\n"+
+		"- repo: hello", summary)
+}
+
+func TestMiddleware(t *testing.T) {
+	tools := testTools()
+	inv := inventory.NewBuilder().SetTools(tools).WithToolsets([]string{"all"}).Build()
+	list, err := Parse([]string{"destructive"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		// action is the answer of the user, or empty if the client does not support elicitation
+		action string
+		dryRun bool
+		// asked is whether the user is asked to confirm the call
+		asked  bool
+		reason string
+	}{
+		{name: "accepted", tool: "delete_file", args: map[string]any{"path": "a"}, action: "accept", asked: true},
+		{name: "declined", tool: "delete_file", args: map[string]any{"path": "a"}, action: "decline", asked: true, reason: ReasonDeclined},
+		{name: "cancelled", tool: "delete_file", args: map[string]any{"path": "a"}, action: "cancel", asked: true, reason: ReasonDeclined},
+		{name: "elicitation not supported", tool: "delete_file", args: map[string]any{"path": "a"}, reason: ReasonUnavailable},
+		{name: "tool not in list", tool: "merge_pull_request", action: "decline"},
+		{name: "dry run", tool: "delete_file", args: map[string]any{"path": "a", "dry_run": true}, action: "decline", dryRun: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+			for _, tool := range tools {
+				server.AddTool(&tool.Tool, tool.Handler(nil))
+			}
+			server.AddReceivingMiddleware(Middleware(list, inv))
+			server.AddReceivingMiddleware(dryrun.Middleware(inv, false))
+
+			var asked []string
+			clientOptions := &mcp.ClientOptions{}
+			if tc.action != "" {
+				clientOptions.ElicitationHandler = func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					asked = append(asked, req.Params.Message)
+					return &mcp.ElicitResult{Action: tc.action}, nil
+				}
+			}
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, clientOptions)
+
+			ctx := context.Background()
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			serverSession, err := server.Connect(ctx, serverTransport, nil)
+			require.NoError(t, err)
+			defer func() { _ = serverSession.Close() }()
+			session, err := client.Connect(ctx, clientTransport, nil)
+			require.NoError(t, err)
+			defer func() { _ = session.Close() }()
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tc.tool, Arguments: tc.args})
+			require.NoError(t, err)
+
+			if tc.asked {
+				require.Len(t, asked, 1)
+				assert.Contains(t, asked[0], "Allow the agent to run Title of delete_file (delete_file)?")
+				assert.Contains(t, asked[0], "- path: a")
+			} else {
+				assert.Empty(t, asked)
+			}
+			if tc.reason == "" {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "done", result.Content[0].(*mcp.TextContent).Text)
+				return
+			}
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tc.tool+" was not run: ")
+			structured, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			var refused refusal
+			require.NoError(t, json.Unmarshal(structured, &refused))
+			assert.Equal(t, tc.reason, refused.Reason)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package dryrun runs write tools without changing anything on GitHub. Tools run as usual,
+// validating their inputs and making the read requests that resolve references, but the
+// first request that would change something is held back and returned to the caller
+// instead of being sent, so that what an agent would do can be reviewed first.
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Argument is the optional argument of write tools that runs a single call as a dry run.
+const Argument = "dry_run"
+
+// ArgumentDescription describes Argument in the input schema of write tools.
+const ArgumentDescription = "If true, validate the inputs and return the GitHub API request the tool would send, without sending it"
+
+// notSentMessage is the message of the responses to held back requests.
+const notSentMessage = "request not sent: dry run"
+
+// Request is a GitHub API request held back by a dry run.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Body is the decoded JSON body of the request, or the body as a string if it is not JSON
+	Body any `json:"body,omitempty"`
+}
+
+// Result is the result of a tool call run as a dry run.
+type Result struct {
+	DryRun   bool      `json:"dry_run"`
+	Tool     string    `json:"tool"`
+	Requests []Request `json:"requests"`
+	Message  string    `json:"message"`
+}
+
+type recorderKey struct{}
+
+// recorder collects the requests held back during a dry run.
+type recorder struct {
+	mu       sync.Mutex
+	requests []Request
+}
+
+func (r *recorder) add(request Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request)
+}
+
+func (r *recorder) held() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+// Enabled reports whether ctx belongs to a tool call run as a dry run.
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(recorderKey{}).(*recorder)
+	return ok
+}
+
+// Middleware returns receiving middleware for an mcp.Server that runs calls of the tools in
+// inv that are not read-only as dry runs, if always is set or the call sets Argument to true.
+// Argument is removed from the arguments before the tool sees them. The GitHub clients must
+// use Transport for their requests to be held back.
+func Middleware(inv *inventory.Inventory, always bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callRequest.Params == nil {
+				return next(ctx, method, req)
+			}
+			tool, _, err := inv.FindToolByName(callRequest.Params.Name)
+			if err != nil || tool.IsReadOnly() {
+				return next(ctx, method, req)
+			}
+
+			requested := takeArgument(callRequest.Params)
+			if !always && !requested {
+				return next(ctx, method, req)
+			}
+
+			rec := &recorder{}
+			result, err := next(context.WithValue(ctx, recorderKey{}, rec), method, req)
+			requests := rec.held()
+			if len(requests) == 0 {
+				// The tool failed before reaching a write, such as on invalid inputs, or had
+				// nothing to write; either way its own result says so
+				return result, err
+			}
+			return newResult(callRequest.Params.Name, requests), nil
+		}
+	}
+}
+
+// takeArgument removes Argument from the arguments of a call, reporting whether it was true.
+func takeArgument(params *mcp.CallToolParamsRaw) bool {
+	var args map[string]any
+	if len(params.Arguments) == 0 || json.Unmarshal(params.Arguments, &args) != nil {
+		return false
+	}
+	value, ok := args[Argument]
+	if !ok {
+		return false
+	}
+	delete(args, Argument)
+	if data, err := json.Marshal(args); err == nil {
+		params.Arguments = data
+	}
+	requested, _ := value.(bool)
+	return requested
+}
+
+func newResult(tool string, requests []Request) *mcp.CallToolResult {
+	result := Result{
+		DryRun:   true,
+		Tool:     tool,
+		Requests: requests,
+		Message:  "Dry run: nothing was changed on GitHub. The tool would send the request below; any requests after it depend on its response, so they are not shown.",
+	}
+	if len(requests) > 1 {
+		result.Message = "Dry run: nothing was changed on GitHub. The tool would send the requests below; any requests after them depend on their responses, so they are not shown."
+	}
+	data, _ := json.Marshal(result)
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		StructuredContent: result,
+	}
+}
+
+// Transport is an http.RoundTripper that holds back the write requests made during a dry
+// run. REST requests other than GET and HEAD, and GraphQL mutations, are writes. Other
+// requests are sent as usual.
+//
+// Held back requests are answered with a 501 Not Implemented response rather than an error,
+// so that they fail like any other GitHub API error in tools that defer closing the bodies
+// of the responses they get.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rec, ok := req.Context().Value(recorderKey{}).(*recorder)
+	if !ok || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/graphql") && !isMutation(body) {
+		// GraphQL queries are sent as POST requests, but only read
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return base.RoundTrip(req)
+	}
+
+	rec.add(Request{Method: req.Method, URL: req.URL.String(), Body: decodeBody(body)})
+	message, _ := json.Marshal(map[string]string{"message": notSentMessage})
+	return &http.Response{
+		Status:        "501 Not Implemented",
+		StatusCode:    http.StatusNotImplemented,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(message)),
+		ContentLength: int64(len(message)),
+		Request:       req,
+	}, nil
+}
+
+// isMutation reports whether the body of a GraphQL request holds a mutation.
+func isMutation(body []byte) bool {
+	var request struct {
+		Query string `json:"query"`
+	}
+	if json.Unmarshal(body, &request) != nil {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(request.Query), "mutation")
+}
+
+func decodeBody(body []byte) any {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	return string(body)
+}
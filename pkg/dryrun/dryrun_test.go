@@ -0,0 +1,222 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTool(name string, readOnly bool) inventory.ServerTool {
+	return inventory.NewServerToolFromHandler(
+		mcp.Tool{
+			Name:        name,
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		inventory.ToolsetMetadata{ID: "test"},
+		func(_ any) mcp.ToolHandler {
+			return func(_ context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return nil, nil
+			}
+		},
+	)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var sent []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	inv := inventory.NewBuilder().
+		SetTools([]inventory.ServerTool{testTool("create_issue", false), testTool("get_issue", true)}).
+		Build()
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     string
+		always   bool
+		invalid  bool
+		expected []string
+		dryRun   bool
+	}{
+		{
+			name:     "dry run requested by the call",
+			tool:     "create_issue",
+			args:     `{"owner":"octo","repo":"hello","dry_run":true}`,
+			expected: []string{"GET /repos/octo/hello"},
+			dryRun:   true,
+		},
+		{
+			name:     "dry run mode",
+			tool:     "create_issue",
+			args:     `{"owner":"octo","repo":"hello"}`,
+			always:   true,
+			expected: []string{"GET /repos/octo/hello"},
+			dryRun:   true,
+		},
+		{
+			name:     "dry run mode cannot be turned off by the call",
+			tool:     "create_issue",
+			args:     `{"owner":"octo","repo":"hello","dry_run":false}`,
+			always:   true,
+			expected: []string{"GET /repos/octo/hello"},
+			dryRun:   true,
+		},
+		{
+			name:     "dry run not requested",
+			tool:     "create_issue",
+			args:     `{"owner":"octo","repo":"hello","dry_run":false}`,
+			expected: []string{"GET /repos/octo/hello", "POST /repos/octo/hello/issues"},
+		},
+		{
+			name:     "read-only tools are run as usual",
+			tool:     "get_issue",
+			args:     `{"owner":"octo","repo":"hello"}`,
+			always:   true,
+			expected: []string{"GET /repos/octo/hello", "POST /repos/octo/hello/issues"},
+		},
+		{
+			name:    "invalid inputs are reported by the tool",
+			tool:    "create_issue",
+			args:    `{"owner":"octo","dry_run":true}`,
+			invalid: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sent = nil
+			var args map[string]any
+			handler := Middleware(inv, tc.always)(func(ctx context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+				params := req.(*mcp.CallToolRequest).Params
+				require.NoError(t, json.Unmarshal(params.Arguments, &args))
+				if tc.invalid {
+					return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "missing required parameter: repo"}}}, nil
+				}
+
+				// Resolve the repository, then write to it
+				getReq, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/repos/octo/hello", nil)
+				require.NoError(t, err)
+				resp, err := client.Do(getReq)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+
+				postReq, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/repos/octo/hello/issues", strings.NewReader(`{"title":"Bug"}`))
+				require.NoError(t, err)
+				resp, err = client.Do(postReq)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: resp.Status}}}, nil
+				}
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "created"}}}, nil
+			})
+
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tc.tool, Arguments: json.RawMessage(tc.args)}}
+			result, err := handler(context.Background(), "tools/call", req)
+			require.NoError(t, err)
+			callResult := result.(*mcp.CallToolResult)
+			assert.Equal(t, tc.expected, sent)
+			assert.NotContains(t, args, Argument, "the tool should not see the dry_run argument")
+
+			switch {
+			case tc.invalid:
+				assert.True(t, callResult.IsError)
+				assert.Equal(t, "missing required parameter: repo", callResult.Content[0].(*mcp.TextContent).Text)
+			case tc.dryRun:
+				assert.False(t, callResult.IsError)
+				dryRunResult, ok := callResult.StructuredContent.(Result)
+				require.True(t, ok)
+				assert.True(t, dryRunResult.DryRun)
+				assert.Equal(t, "create_issue", dryRunResult.Tool)
+				require.Len(t, dryRunResult.Requests, 1)
+				assert.Equal(t, http.MethodPost, dryRunResult.Requests[0].Method)
+				assert.Equal(t, ts.URL+"/repos/octo/hello/issues", dryRunResult.Requests[0].URL)
+				assert.JSONEq(t, `{"title":"Bug"}`, string(dryRunResult.Requests[0].Body.(json.RawMessage)))
+				assert.Contains(t, callResult.Content[0].(*mcp.TextContent).Text, `"dry_run":true`)
+			default:
+				assert.False(t, callResult.IsError)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	rec := &recorder{}
+	ctx := context.WithValue(context.Background(), recorderKey{}, rec)
+	assert.True(t, Enabled(ctx))
+	assert.False(t, Enabled(context.Background()))
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		path   string
+		body   string
+		sent   bool
+	}{
+		{name: "GET", ctx: ctx, method: http.MethodGet, path: "/repos/octo/hello", sent: true},
+		{name: "GraphQL query", ctx: ctx, method: http.MethodPost, path: "/graphql", body: `{"query":"query{viewer{login}}"}`, sent: true},
+		{name: "GraphQL mutation", ctx: ctx, method: http.MethodPost, path: "/graphql", body: `{"query":"mutation($input:AddCommentInput!){addComment(input:$input){clientMutationId}}","variables":{"input":{"body":"hi"}}}`},
+		{name: "DELETE", ctx: ctx, method: http.MethodDelete, path: "/repos/octo/hello/git/refs/heads/old"},
+		{name: "PUT", ctx: ctx, method: http.MethodPut, path: "/repos/octo/hello/contents/README.md", body: "not json"},
+		{name: "write outside a dry run", ctx: context.Background(), method: http.MethodPost, path: "/repos/octo/hello/issues", body: `{"title":"Bug"}`, sent: true},
+	}
+
+	var held []Request
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = nil
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req, err := http.NewRequestWithContext(tc.ctx, tc.method, ts.URL+tc.path, body)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			if !tc.sent {
+				assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+				assert.Empty(t, received)
+				held = append(held, Request{Method: tc.method, URL: ts.URL + tc.path, Body: decodeBody([]byte(tc.body))})
+				return
+			}
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			// The body of requests that are sent is left intact
+			assert.Equal(t, []string{tc.method + " " + tc.path + " " + tc.body}, received)
+		})
+	}
+
+	assert.Equal(t, held, rec.held())
+	assert.Equal(t, "not json", rec.held()[2].Body)
+	assert.Nil(t, rec.held()[1].Body)
+}
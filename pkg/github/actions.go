@@ -57,8 +57,9 @@ func ListWorkflows(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_workflows",
 			Description: t("TOOL_LIST_WORKFLOWS_DESCRIPTION", "List workflows in a repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -129,8 +130,9 @@ func ListWorkflowRuns(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "list_workflow_runs",
 			Description: t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs for a specific workflow"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -284,8 +286,10 @@ func RunWorkflow(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "run_workflow",
 			Description: t("TOOL_RUN_WORKFLOW_DESCRIPTION", "Run an Actions workflow by workflow ID or filename"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_RUN_WORKFLOW_USER_TITLE", "Run workflow"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_RUN_WORKFLOW_USER_TITLE", "Run workflow"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -397,8 +401,9 @@ func GetWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_workflow_run",
 			Description: t("TOOL_GET_WORKFLOW_RUN_DESCRIPTION", "Get details of a specific workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -466,8 +471,9 @@ func GetWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.ServerTo
 			Name:        "get_workflow_run_logs",
 			Description: t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using get_job_logs with failed_only=true for debugging failed jobs)"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_WORKFLOW_RUN_LOGS_USER_TITLE", "Get workflow run logs"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_WORKFLOW_RUN_LOGS_USER_TITLE", "Get workflow run logs"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -545,8 +551,9 @@ func ListWorkflowJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "list_workflow_jobs",
 			Description: t("TOOL_LIST_WORKFLOW_JOBS_DESCRIPTION", "List jobs for a specific workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_WORKFLOW_JOBS_USER_TITLE", "List workflow jobs"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_WORKFLOW_JOBS_USER_TITLE", "List workflow jobs"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -646,8 +653,9 @@ func GetJobLogs(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_job_logs",
 			Description: t("TOOL_GET_JOB_LOGS_DESCRIPTION", "Download logs for a specific workflow job or efficiently get all failed job logs for a workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_JOB_LOGS_USER_TITLE", "Get job logs"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_JOB_LOGS_USER_TITLE", "Get job logs"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -686,7 +694,7 @@ func GetJobLogs(t translations.TranslationHelperFunc) inventory.ServerTool {
 			},
 		},
 		[]scopes.Scope{scopes.Repo},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			client, err := deps.GetClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
@@ -737,7 +745,7 @@ func GetJobLogs(t translations.TranslationHelperFunc) inventory.ServerTool {
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, deps.GetContentWindowSize())
+				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, deps.GetContentWindowSize(), newProgressReporter(req))
 			} else if jobID > 0 {
 				// Handle single job mode
 				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, deps.GetContentWindowSize())
@@ -750,8 +758,8 @@ func GetJobLogs(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return tool
 }
 
-// handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
+// handleFailedJobLogs gets logs for all failed jobs in a workflow run, reporting progress after each job
+func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int, progress *progressReporter) (*mcp.CallToolResult, any, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -782,7 +790,13 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 
 	// Collect logs for all failed jobs
 	var logResults []map[string]any
-	for _, job := range failedJobs {
+	var downloaded int
+	for i, job := range failedJobs {
+		// Stop downloading if the client cancelled the request
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
 		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
@@ -796,6 +810,13 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		}
 
 		logResults = append(logResults, jobResult)
+
+		message := fmt.Sprintf("Retrieved logs for %d of %d failed jobs", i+1, len(failedJobs))
+		if content, ok := jobResult["logs_content"].(string); ok {
+			downloaded += len(content)
+			message += fmt.Sprintf(" (%d bytes)", downloaded)
+		}
+		progress.report(ctx, float64(i+1), float64(len(failedJobs)), message)
 	}
 
 	result := map[string]any{
@@ -873,7 +894,11 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 	prof := profiler.New(nil, profiler.IsProfilingEnabled())
 	finish := prof.Start(ctx, "log_buffer_processing")
 
-	httpResp, err := http.Get(logURL) //nolint:gosec
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to create log download request: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req) //nolint:gosec
 	if err != nil {
 		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
@@ -912,8 +937,10 @@ func RerunWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "rerun_workflow_run",
 			Description: t("TOOL_RERUN_WORKFLOW_RUN_DESCRIPTION", "Re-run an entire workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_RERUN_WORKFLOW_RUN_USER_TITLE", "Rerun workflow run"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_RERUN_WORKFLOW_RUN_USER_TITLE", "Rerun workflow run"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -988,8 +1015,10 @@ func RerunFailedJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "rerun_failed_jobs",
 			Description: t("TOOL_RERUN_FAILED_JOBS_DESCRIPTION", "Re-run only the failed jobs in a workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Rerun failed jobs"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Rerun failed jobs"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1064,8 +1093,11 @@ func CancelWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerToo
 			Name:        "cancel_workflow_run",
 			Description: t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel a workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1142,8 +1174,9 @@ func ListWorkflowRunArtifacts(t translations.TranslationHelperFunc) inventory.Se
 			Name:        "list_workflow_run_artifacts",
 			Description: t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_DESCRIPTION", "List artifacts for a workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -1223,8 +1256,9 @@ func DownloadWorkflowRunArtifact(t translations.TranslationHelperFunc) inventory
 			Name:        "download_workflow_run_artifact",
 			Description: t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_DESCRIPTION", "Get download URL for a workflow run artifact"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1304,6 +1338,8 @@ func DeleteWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.Serve
 				Title:           t("TOOL_DELETE_WORKFLOW_RUN_LOGS_USER_TITLE", "Delete workflow logs"),
 				ReadOnlyHint:    false,
 				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1378,8 +1414,9 @@ func GetWorkflowRunUsage(t translations.TranslationHelperFunc) inventory.ServerT
 			Name:        "get_workflow_run_usage",
 			Description: t("TOOL_GET_WORKFLOW_RUN_USAGE_DESCRIPTION", "Get usage metrics for a workflow run"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1450,8 +1487,9 @@ func ActionsList(t translations.TranslationHelperFunc) inventory.ServerTool {
 Use this tool to list workflows in a repository, or list workflow runs, jobs, and artifacts for a specific workflow or workflow run.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_ACTIONS_LIST_USER_TITLE", "List GitHub Actions workflows in a repository"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_ACTIONS_LIST_USER_TITLE", "List GitHub Actions workflows in a repository"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1645,8 +1683,9 @@ func ActionsGet(t translations.TranslationHelperFunc) inventory.ServerTool {
 Use this tool to get details about individual workflows, workflow runs, jobs, and artifacts by their unique IDs.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_ACTIONS_GET_USER_TITLE", "Get details of GitHub Actions resources (workflows, workflow runs, jobs, and artifacts)"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_ACTIONS_GET_USER_TITLE", "Get details of GitHub Actions resources (workflows, workflow runs, jobs, and artifacts)"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1755,6 +1794,7 @@ func ActionsRunTrigger(t translations.TranslationHelperFunc) inventory.ServerToo
 				Title:           t("TOOL_ACTIONS_RUN_TRIGGER_USER_TITLE", "Trigger GitHub Actions workflow actions"),
 				ReadOnlyHint:    false,
 				DestructiveHint: jsonschema.Ptr(true),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1874,8 +1914,9 @@ Use this tool to retrieve logs for a specific job or all failed jobs in a workfl
 For single job logs, provide job_id. For all failed jobs in a run, provide run_id with failed_only=true.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_JOB_LOGS_CONSOLIDATED_USER_TITLE", "Get GitHub Actions workflow job logs"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_JOB_LOGS_CONSOLIDATED_USER_TITLE", "Get GitHub Actions workflow job logs"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1914,7 +1955,7 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 			},
 		},
 		[]scopes.Scope{scopes.Repo},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1968,7 +2009,7 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, deps.GetContentWindowSize())
+				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, deps.GetContentWindowSize(), newProgressReporter(req))
 			} else if jobID > 0 {
 				// Handle single job mode
 				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, deps.GetContentWindowSize())
@@ -1103,6 +1103,55 @@ func Test_GetJobLogs_WithContentReturn(t *testing.T) {
 	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
 }
 
+func Test_GetJobLogs_FailedJobsStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client gives up while the first job's logs are downloading
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		cancel()
+		_, _ = w.Write([]byte("log line"))
+	}))
+	defer testServer.Close()
+
+	var requestedJobs []string
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposActionsRunsJobsByOwnerByRepoByRunID: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(&github.Jobs{
+				TotalCount: github.Ptr(2),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+				},
+			})
+		}),
+		GetReposActionsJobsLogsByOwnerByRepoByJobID: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedJobs = append(requestedJobs, r.URL.Path)
+			w.Header().Set("Location", testServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	})
+
+	client := github.NewClient(mockedClient)
+	deps := BaseDeps{
+		Client:            client,
+		ContentWindowSize: 5000,
+	}
+	toolDef := GetJobLogs(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"run_id":         float64(456),
+		"failed_only":    true,
+		"return_content": true,
+	})
+	_, err := handler(ContextWithDeps(ctx, deps), &request)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, requestedJobs, 1)
+}
+
 func Test_GetJobLogs_WithContentReturnAndTailLines(t *testing.T) {
 	// Test the return_content functionality with a mock HTTP server
 	logContent := "2023-01-01T10:00:00.000Z Starting job...\n2023-01-01T10:00:01.000Z Running tests...\n2023-01-01T10:00:02.000Z Job completed successfully"
@@ -7,6 +7,7 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -45,8 +46,9 @@ func GetMe(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_me",
 			Description: t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			// Use json.RawMessage to ensure "properties" is included even when empty.
 			// OpenAI strict mode requires the properties field to be present.
@@ -118,8 +120,9 @@ func GetTeams(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_teams",
 			Description: t("TOOL_GET_TEAMS_DESCRIPTION", "Get details of the teams the user is a member of. Limited to organizations accessible with current credentials"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_TEAMS_TITLE", "Get teams"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_TEAMS_TITLE", "Get teams"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -216,8 +219,9 @@ func GetTeamMembers(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_team_members",
 			Description: t("TOOL_GET_TEAM_MEMBERS_DESCRIPTION", "Get member usernames of a specific team in an organization. Limited to organizations accessible with current credentials"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_TEAM_MEMBERS_TITLE", "Get team members"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_TEAM_MEMBERS_TITLE", "Get team members"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -279,3 +283,66 @@ func GetTeamMembers(t translations.TranslationHelperFunc) inventory.ServerTool {
 		},
 	)
 }
+
+// RateLimitStatus is the remaining GitHub API budget returned by get_rate_limit.
+type RateLimitStatus struct {
+	Resources []ratelimit.Budget `json:"resources"`
+}
+
+// GetRateLimit creates a tool to get the remaining GitHub API rate limit budget.
+func GetRateLimit(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_rate_limit",
+			Description: t("TOOL_GET_RATE_LIMIT_DESCRIPTION", "Get the remaining GitHub API rate limit budget for REST (core), search and GraphQL requests, and when each resets. Use this before long-running tasks such as paging through many results."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:         t("TOOL_GET_RATE_LIMIT_TITLE", "Get API rate limit"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			tracker := deps.GetRateLimits()
+			if tracker == nil {
+				tracker = ratelimit.NewTracker()
+			}
+
+			// The tracker only knows about resources that have been used, so ask the API
+			// for the rest. Requests to the rate limit API do not count against the limit.
+			if !hasCurrentBudgets(tracker, ratelimit.ResourceCore, ratelimit.ResourceSearch, ratelimit.ResourceGraphQL) {
+				client, err := deps.GetClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+				}
+
+				limits, res, err := client.RateLimit.Get(ctx)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get rate limit",
+						res,
+						err,
+					), nil, nil
+				}
+				tracker.UpdateFromRateLimits(limits)
+			}
+
+			return MarshalledTextResult(RateLimitStatus{Resources: tracker.Budgets()}), nil, nil
+		},
+	)
+}
+
+// hasCurrentBudgets reports whether the tracker has a budget that has not yet reset for every resource.
+func hasCurrentBudgets(tracker *ratelimit.Tracker, resources ...string) bool {
+	for _, resource := range resources {
+		budget, ok := tracker.Budget(resource)
+		if !ok || time.Now().After(budget.Reset) {
+			return false
+		}
+	}
+	return true
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v79/github"
 	"github.com/shurcooL/githubv4"
@@ -513,3 +514,108 @@ func Test_GetTeamMembers(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRateLimit(t *testing.T) {
+	t.Parallel()
+
+	serverTool := GetRateLimit(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rate_limit", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_rate_limit tool should be read-only")
+
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	mockRateLimits := map[string]any{
+		"resources": map[string]any{
+			"core":    map[string]any{"limit": 5000, "remaining": 4990, "used": 10, "reset": reset.Unix()},
+			"search":  map[string]any{"limit": 30, "remaining": 29, "used": 1, "reset": reset.Unix()},
+			"graphql": map[string]any{"limit": 5000, "remaining": 4000, "used": 1000, "reset": reset.Unix()},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		tracker            func() *ratelimit.Tracker
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedCore       int
+		expectedGraphQL    int
+	}{
+		{
+			name: "fetches budgets not yet tracked",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetRateLimitEndpoint: mockResponse(t, http.StatusOK, mockRateLimits),
+			}),
+			tracker:         ratelimit.NewTracker,
+			expectedCore:    4990,
+			expectedGraphQL: 4000,
+		},
+		{
+			name: "uses tracked budgets without calling the API",
+			tracker: func() *ratelimit.Tracker {
+				tracker := ratelimit.NewTracker()
+				tracker.Update(ratelimit.Budget{Resource: ratelimit.ResourceCore, Limit: 5000, Remaining: 12, Reset: reset})
+				tracker.Update(ratelimit.Budget{Resource: ratelimit.ResourceSearch, Limit: 30, Remaining: 30, Reset: reset})
+				tracker.Update(ratelimit.Budget{Resource: ratelimit.ResourceGraphQL, Limit: 5000, Remaining: 7, Reset: reset})
+				return tracker
+			},
+			expectedCore:    12,
+			expectedGraphQL: 7,
+		},
+		{
+			name: "works without a tracker",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetRateLimitEndpoint: mockResponse(t, http.StatusOK, mockRateLimits),
+			}),
+			tracker:         func() *ratelimit.Tracker { return nil },
+			expectedCore:    4990,
+			expectedGraphQL: 4000,
+		},
+		{
+			name: "rate limit API fails",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetRateLimitEndpoint: badRequestHandler("expected test failure"),
+			}),
+			tracker:            ratelimit.NewTracker,
+			expectToolError:    true,
+			expectedToolErrMsg: "expected test failure",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:     github.NewClient(tc.mockedClient),
+				RateLimits: tc.tracker(),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError, "expected tool call result to be an error")
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var status RateLimitStatus
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &status))
+
+			budgets := make(map[string]ratelimit.Budget)
+			for _, budget := range status.Resources {
+				budgets[budget.Resource] = budget
+			}
+			assert.Equal(t, tc.expectedCore, budgets[ratelimit.ResourceCore].Remaining)
+			assert.Equal(t, tc.expectedGraphQL, budgets[ratelimit.ResourceGraphQL].Remaining)
+			assert.True(t, reset.Equal(budgets[ratelimit.ResourceCore].Reset))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -77,6 +78,9 @@ type ToolDependencies interface {
 
 	// GetContentWindowSize returns the content window size for log truncation
 	GetContentWindowSize() int
+
+	// GetRateLimits returns the tracker of GitHub API rate limit budgets, or nil if not tracked
+	GetRateLimits() *ratelimit.Tracker
 }
 
 // BaseDeps is the standard implementation of ToolDependencies for the local server.
@@ -90,6 +94,7 @@ type BaseDeps struct {
 
 	// Static dependencies
 	RepoAccessCache   *lockdown.RepoAccessCache
+	RateLimits        *ratelimit.Tracker
 	T                 translations.TranslationHelperFunc
 	Flags             FeatureFlags
 	ContentWindowSize int
@@ -143,6 +148,9 @@ func (d BaseDeps) GetFlags() FeatureFlags { return d.Flags }
 // GetContentWindowSize implements ToolDependencies.
 func (d BaseDeps) GetContentWindowSize() int { return d.ContentWindowSize }
 
+// GetRateLimits implements ToolDependencies.
+func (d BaseDeps) GetRateLimits() *ratelimit.Tracker { return d.RateLimits }
+
 // NewTool creates a ServerTool that retrieves ToolDependencies from context at call time.
 // This avoids creating closures at registration time, which is important for performance
 // in servers that create a new server instance per request (like the remote server).
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"maps"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// withDryRunArgument adds the optional dry_run argument to the input schema of every tool that
+// is not read-only, so that a single call can be run as a dry run. The schemas are copied, as
+// tool definitions are shared.
+func withDryRunArgument(tools []inventory.ServerTool) []inventory.ServerTool {
+	result := make([]inventory.ServerTool, len(tools))
+	for i, tool := range tools {
+		result[i] = tool
+		schema, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+		if tool.IsReadOnly() || !ok || schema == nil {
+			continue
+		}
+		withArgument := *schema
+		withArgument.Properties = maps.Clone(schema.Properties)
+		if withArgument.Properties == nil {
+			withArgument.Properties = map[string]*jsonschema.Schema{}
+		}
+		withArgument.Properties[dryrun.Argument] = &jsonschema.Schema{
+			Type:        "boolean",
+			Description: dryrun.ArgumentDescription,
+		}
+		result[i].Tool.InputSchema = &withArgument
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDryRunArgument(t *testing.T) {
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	mergeTool, _, err := inv.FindToolByName("merge_pull_request")
+	require.NoError(t, err)
+	schema := mergeTool.Tool.InputSchema.(*jsonschema.Schema)
+	require.Contains(t, schema.Properties, dryrun.Argument)
+	assert.Equal(t, "boolean", schema.Properties[dryrun.Argument].Type)
+	assert.NotContains(t, schema.Required, dryrun.Argument)
+
+	readTool, _, err := inv.FindToolByName("get_file_contents")
+	require.NoError(t, err)
+	assert.NotContains(t, readTool.Tool.InputSchema.(*jsonschema.Schema).Properties, dryrun.Argument)
+
+	// The tool definitions themselves are left as they are
+	definition := MergePullRequest(translations.NullTranslationHelper)
+	assert.NotContains(t, definition.Tool.InputSchema.(*jsonschema.Schema).Properties, dryrun.Argument)
+}
+
+func TestDryRun_PushFiles(t *testing.T) {
+	mockedClient := NewMockedHTTPClient(
+		WithRequestMatch(GetReposGitRefByOwnerByRepoByRef, &github.Reference{
+			Ref:    github.Ptr("refs/heads/main"),
+			Object: &github.GitObject{SHA: github.Ptr("abc123")},
+		}),
+		WithRequestMatch(GetReposGitCommitsByOwnerByRepoByCommitSHA, &github.Commit{
+			SHA:  github.Ptr("abc123"),
+			Tree: &github.Tree{SHA: github.Ptr("def456")},
+		}),
+		WithRequestMatchHandler(PostReposGitTreesByOwnerByRepo, func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("the tree should not be created in a dry run")
+			w.WriteHeader(http.StatusInternalServerError)
+		}),
+	)
+	client := github.NewClient(&http.Client{Transport: &dryrun.Transport{Base: mockedClient.Transport}})
+	deps := BaseDeps{Client: client}
+
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+	tool, _, err := inv.FindToolByName("push_files")
+	require.NoError(t, err)
+	toolHandler := tool.Handler(deps)
+	handler := dryrun.Middleware(inv, false)(func(ctx context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		return toolHandler(ctx, req.(*mcp.CallToolRequest))
+	})
+
+	request := createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"branch":  "main",
+		"message": "Update README",
+		"files":   []any{map[string]any{"path": "README.md", "content": "# Hello"}},
+		"dry_run": true,
+	})
+	request.Params.Name = "push_files"
+	result, err := handler(ContextWithDeps(context.Background(), deps), "tools/call", &request)
+	require.NoError(t, err)
+	callResult := result.(*mcp.CallToolResult)
+	require.False(t, callResult.IsError)
+
+	var dryRunResult dryrun.Result
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, callResult).Text), &dryRunResult))
+	require.Len(t, dryRunResult.Requests, 1)
+	held := dryRunResult.Requests[0]
+	assert.Equal(t, http.MethodPost, held.Method)
+	assert.Equal(t, "https://api.github.com/repos/owner/repo/git/trees", held.URL)
+	// The base tree was resolved from the branch before the request was held back
+	assert.Equal(t, map[string]any{
+		"base_tree": "def456",
+		"tree": []any{map[string]any{
+			"path":    "README.md",
+			"mode":    "100644",
+			"type":    "blob",
+			"content": "# Hello",
+		}},
+	}, held.Body)
+}
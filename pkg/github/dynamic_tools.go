@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -54,6 +56,9 @@ func DynamicTools(r *inventory.Inventory) []inventory.ServerTool {
 		ListAvailableToolsets(),
 		GetToolsetsTools(r),
 		EnableToolset(r),
+		DisableToolset(r),
+		SearchTools(),
+		ExplainTool(),
 	}
 }
 
@@ -65,8 +70,9 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 			Name:        "enable_toolset",
 			Description: "Enable one of the sets of tools the GitHub MCP server provides, use get_toolset_tools and list_available_toolsets first to see what this will enable",
 			Annotations: &mcp.ToolAnnotations{
-				Title:        "Enable a toolset",
-				ReadOnlyHint: true,
+				Title:         "Enable a toolset",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -81,7 +87,7 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 			},
 		},
 		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
-			return func(_ context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 				toolsetName, err := RequiredParam[string](args, "toolset")
 				if err != nil {
 					return utils.NewToolResultError(err.Error()), nil, nil
@@ -100,10 +106,25 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 				// Mark the toolset as enabled so IsToolsetEnabled returns true
 				deps.Inventory.EnableToolset(toolsetID)
 
-				// Get tools for this toolset and register them with the managed deps
-				toolsForToolset := deps.Inventory.ToolsForToolset(toolsetID)
-				for _, st := range toolsForToolset {
-					st.RegisterFunc(deps.Server, deps.ToolDeps)
+				// Register the toolset's tools that pass the other filters, such as excluded
+				// tools and feature flags, with the managed deps. The tools of a custom
+				// toolset belong to other toolsets, so membership is looked up by name
+				members := make(map[string]bool)
+				for _, st := range deps.Inventory.ToolsForToolset(toolsetID) {
+					members[st.Tool.Name] = true
+				}
+				var toolsForToolset []inventory.ServerTool
+				for _, st := range deps.Inventory.AvailableTools(ctx) {
+					if members[st.Tool.Name] {
+						toolsForToolset = append(toolsForToolset, st)
+						st.RegisterFunc(deps.Server, deps.ToolDeps)
+					}
+				}
+				for _, res := range deps.Inventory.ResourceTemplatesForToolset(ctx, toolsetID) {
+					res.RegisterFunc(deps.Server, deps.ToolDeps)
+				}
+				for _, prompt := range deps.Inventory.PromptsForToolset(ctx, toolsetID) {
+					prompt.RegisterFunc(deps.Server)
 				}
 
 				return utils.NewToolResultText(fmt.Sprintf("Toolset %s enabled with %d tools", toolsetName, len(toolsForToolset))), nil, nil
@@ -112,6 +133,88 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 	)
 }
 
+// DisableToolset creates a tool that disables a toolset at runtime, removing its tools,
+// resources and prompts from the server. The server notifies clients that its lists changed.
+func DisableToolset(r *inventory.Inventory) inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "disable_toolset",
+			Description: "Disable one of the enabled sets of tools the GitHub MCP server provides, removing its tools from the ones available to you. Use this when a task no longer needs a toolset, to keep the list of tools short. Tools enabled individually stay available",
+			Annotations: &mcp.ToolAnnotations{
+				Title:         "Disable a toolset",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"toolset": {
+						Type:        "string",
+						Description: "The name of the toolset to disable",
+						Enum:        toolsetIDsEnum(r),
+					},
+				},
+				Required: []string{"toolset"},
+			},
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				toolsetName, err := RequiredParam[string](args, "toolset")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				toolsetID := inventory.ToolsetID(toolsetName)
+
+				if !deps.Inventory.HasToolset(toolsetID) {
+					return utils.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil, nil
+				}
+
+				if !deps.Inventory.IsToolsetEnabled(toolsetID) {
+					return utils.NewToolResultText(fmt.Sprintf("Toolset %s is not enabled", toolsetName)), nil, nil
+				}
+
+				deps.Inventory.DisableToolset(toolsetID)
+
+				// Keep the tools that are still available without the toolset, such as tools
+				// enabled individually
+				stillAvailable := make(map[string]bool)
+				for _, st := range deps.Inventory.AvailableTools(ctx) {
+					stillAvailable[st.Tool.Name] = true
+				}
+				var toolNames []string
+				for _, st := range deps.Inventory.ToolsForToolset(toolsetID) {
+					if !stillAvailable[st.Tool.Name] {
+						toolNames = append(toolNames, st.Tool.Name)
+					}
+				}
+				var uriTemplates []string
+				for _, res := range deps.Inventory.ResourceTemplatesForToolset(ctx, toolsetID) {
+					uriTemplates = append(uriTemplates, res.Template.URITemplate)
+				}
+				var promptNames []string
+				for _, prompt := range deps.Inventory.PromptsForToolset(ctx, toolsetID) {
+					promptNames = append(promptNames, prompt.Prompt.Name)
+				}
+
+				// Each removal notifies clients of the list it changed
+				if len(toolNames) > 0 {
+					deps.Server.RemoveTools(toolNames...)
+				}
+				if len(uriTemplates) > 0 {
+					deps.Server.RemoveResourceTemplates(uriTemplates...)
+				}
+				if len(promptNames) > 0 {
+					deps.Server.RemovePrompts(promptNames...)
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("Toolset %s disabled, removing %d tools", toolsetName, len(toolNames))), nil, nil
+			}
+		},
+	)
+}
+
 // ListAvailableToolsets creates a tool that lists all available inventory.
 func ListAvailableToolsets() inventory.ServerTool {
 	return NewDynamicTool(
@@ -120,8 +223,9 @@ func ListAvailableToolsets() inventory.ServerTool {
 			Name:        "list_available_toolsets",
 			Description: "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call",
 			Annotations: &mcp.ToolAnnotations{
-				Title:        "List available toolsets",
-				ReadOnlyHint: true,
+				Title:         "List available toolsets",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
@@ -163,8 +267,9 @@ func GetToolsetsTools(r *inventory.Inventory) inventory.ServerTool {
 			Name:        "get_toolset_tools",
 			Description: "Lists all the capabilities that are enabled with the specified toolset, use this to get clarity on whether enabling a toolset would help you to complete a task",
 			Annotations: &mcp.ToolAnnotations{
-				Title:        "List all tools in a toolset",
-				ReadOnlyHint: true,
+				Title:         "List all tools in a toolset",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -215,3 +320,174 @@ func GetToolsetsTools(r *inventory.Inventory) inventory.ServerTool {
 		},
 	)
 }
+
+// Result limits of the search_tools tool.
+const (
+	searchToolsDefaultLimit = 5
+	searchToolsMaxLimit     = 20
+)
+
+// SearchTools creates a tool that finds tools matching a natural-language query across all
+// toolsets, and optionally enables the individual tools found.
+func SearchTools() inventory.ServerTool {
+	// The index is built from the inventory on first use
+	var indexOnce sync.Once
+	var index *inventory.ToolIndex
+
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "search_tools",
+			Description: "Search all the tools this GitHub MCP server can offer for the ones that match a task described in plain words, across every toolset, enabled or not. Use this instead of guessing which toolset to enable; set enable to make just the matching tools available without their whole toolsets",
+			Annotations: &mcp.ToolAnnotations{
+				Title:         "Search tools",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "What you want to do, e.g. \"list failed workflow runs\" or \"comment on a pull request\"",
+					},
+					"limit": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of tools to return (default %d, max %d)", searchToolsDefaultLimit, searchToolsMaxLimit),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(searchToolsMaxLimit)),
+					},
+					"enable": {
+						Type:        "boolean",
+						Description: "Enable the tools found so that you can call them, without enabling their toolsets",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				query, err := RequiredParam[string](args, "query")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				limit, err := OptionalIntParamWithDefault(args, "limit", searchToolsDefaultLimit)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				limit = max(1, min(limit, searchToolsMaxLimit))
+				enable, err := OptionalParam[bool](args, "enable")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				indexOnce.Do(func() {
+					index = inventory.NewToolIndex(deps.Inventory.AllTools())
+				})
+
+				// Only offer tools that enabling would make available, leaving out write
+				// tools in read-only mode and tools behind feature flags
+				enabled := make(map[string]bool)
+				var matches []inventory.ToolMatch
+				for _, match := range index.Search(query, 0) {
+					decisions, err := deps.Inventory.ExplainTool(ctx, match.Tool.Tool.Name)
+					if err != nil {
+						continue
+					}
+					canEnable := false
+					for _, decision := range decisions {
+						enabled[decision.Tool] = enabled[decision.Tool] || decision.Enabled
+						canEnable = canEnable || decision.Enabled || decision.Stage == inventory.FilterStageToolset
+					}
+					if canEnable {
+						matches = append(matches, match)
+					}
+					if len(matches) == limit {
+						break
+					}
+				}
+
+				if enable {
+					var toEnable []string
+					for _, match := range matches {
+						if !enabled[match.Tool.Tool.Name] {
+							toEnable = append(toEnable, match.Tool.Tool.Name)
+						}
+					}
+					if len(toEnable) > 0 {
+						deps.Inventory.EnableTools(toEnable...)
+						for _, st := range deps.Inventory.AvailableTools(ctx) {
+							if slices.Contains(toEnable, st.Tool.Name) {
+								st.RegisterFunc(deps.Server, deps.ToolDeps)
+								enabled[st.Tool.Name] = true
+							}
+						}
+					}
+				}
+
+				payload := make([]map[string]string, 0, len(matches))
+				for _, match := range matches {
+					payload = append(payload, map[string]string{
+						"name":              match.Tool.Tool.Name,
+						"description":       match.Tool.Tool.Description,
+						"toolset":           string(match.Tool.Toolset.ID),
+						"currently_enabled": fmt.Sprintf("%t", enabled[match.Tool.Tool.Name]),
+					})
+				}
+
+				r, err := json.Marshal(payload)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal tools: %w", err)
+				}
+
+				return utils.NewToolResultText(string(r)), nil, nil
+			}
+		},
+	)
+}
+
+// ExplainTool creates a tool that explains why a tool is or is not available.
+func ExplainTool() inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "explain_tool",
+			Description: "Explain why a tool is or is not available, listing each filter it passed or failed: feature flags, read-only mode, token scopes and whether its toolset is enabled. Use this when a tool you expect is missing, before enabling a toolset for it",
+			Annotations: &mcp.ToolAnnotations{
+				Title:         "Explain a tool's availability",
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"tool": {
+						Type:        "string",
+						Description: "The name of the tool to explain",
+					},
+				},
+				Required: []string{"tool"},
+			},
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				toolName, err := RequiredParam[string](args, "tool")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				decisions, err := deps.Inventory.ExplainTool(ctx, toolName)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("Tool %s not found", toolName)), nil, nil
+				}
+
+				r, err := json.Marshal(decisions)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal tool decisions: %w", err)
+				}
+
+				return utils.NewToolResultText(string(r)), nil, nil
+			}
+		},
+	)
+}
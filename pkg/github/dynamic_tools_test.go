@@ -3,7 +3,10 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -168,6 +171,222 @@ func TestDynamicTools_EnableToolset(t *testing.T) {
 	assert.Contains(t, textContent2.Text, "already enabled")
 }
 
+func TestDynamicTools_DisableToolset(t *testing.T) {
+	// Build a registry with repos enabled, and one repos tool enabled individually
+	reg := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{"repos"}).
+		WithTools([]string{"get_file_contents"}).
+		Build()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	toolDeps := NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0)
+	reg.RegisterAll(context.Background(), server, toolDeps)
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  toolDeps,
+		T:         translations.NullTranslationHelper,
+	}
+	for _, tool := range DynamicTools(reg) {
+		tool.RegisterFunc(server, deps)
+	}
+
+	var toolListChanged, resourceListChanged atomic.Int32
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(_ context.Context, _ *mcp.ToolListChangedRequest) {
+			toolListChanged.Add(1)
+		},
+		ResourceListChangedHandler: func(_ context.Context, _ *mcp.ResourceListChangedRequest) {
+			resourceListChanged.Add(1)
+		},
+	})
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = serverSession.Close() }()
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	toolNames := func() map[string]bool {
+		tools, err := session.ListTools(ctx, nil)
+		require.NoError(t, err)
+		names := make(map[string]bool)
+		for _, tool := range tools.Tools {
+			names[tool.Name] = true
+		}
+		return names
+	}
+	before := toolNames()
+	require.True(t, before["create_branch"])
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, templates.ResourceTemplates)
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "disable_toolset", Arguments: map[string]any{"toolset": "repos"}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Toolset repos disabled")
+	assert.False(t, reg.IsToolsetEnabled(inventory.ToolsetID("repos")))
+
+	// The toolset's tools and resources are gone, except the tool enabled individually
+	after := toolNames()
+	assert.False(t, after["create_branch"])
+	assert.True(t, after["get_file_contents"])
+	assert.True(t, after["disable_toolset"])
+	templates, err = session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, templates.ResourceTemplates)
+
+	// The client is told its lists changed
+	require.Eventually(t, func() bool {
+		return toolListChanged.Load() > 0 && resourceListChanged.Load() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Disabling again reports the toolset is not enabled
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "disable_toolset", Arguments: map[string]any{"toolset": "repos"}})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "is not enabled")
+
+	// Enabling it again brings the resources back
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "enable_toolset", Arguments: map[string]any{"toolset": "repos"}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.True(t, toolNames()["create_branch"])
+	templates, err = session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, templates.ResourceTemplates)
+}
+
+func TestDynamicTools_SearchTools(t *testing.T) {
+	// Build a registry with no toolsets enabled (dynamic mode), in read-only mode
+	reg := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		WithReadOnly(true).
+		Build()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0),
+		T:         translations.NullTranslationHelper,
+	}
+
+	tool := SearchTools()
+	handler := tool.Handler(deps)
+
+	search := func(args map[string]any) []map[string]string {
+		result, err := handler(context.Background(), createDynamicRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var tools []map[string]string
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &tools))
+		return tools
+	}
+
+	// Write tools can't be enabled in read-only mode, so they are not offered
+	tools := search(map[string]any{"query": "dependabot alerts", "limit": 2})
+	require.Len(t, tools, 2)
+	for _, tool := range tools {
+		assert.Equal(t, "dependabot", tool["toolset"])
+		assert.Equal(t, "false", tool["currently_enabled"])
+	}
+	for _, tool := range search(map[string]any{"query": "merge pull request", "limit": 20}) {
+		assert.NotEqual(t, "merge_pull_request", tool["name"])
+	}
+
+	// Enabling registers just the tools found, not their toolset
+	tools = search(map[string]any{"query": "list dependabot alerts", "limit": 1, "enable": true})
+	require.Len(t, tools, 1)
+	assert.Equal(t, "list_dependabot_alerts", tools[0]["name"])
+	assert.Equal(t, "true", tools[0]["currently_enabled"])
+	assert.False(t, reg.IsToolsetEnabled(inventory.ToolsetID("dependabot")))
+
+	var available []string
+	for _, st := range reg.AvailableTools(context.Background()) {
+		available = append(available, st.Tool.Name)
+	}
+	assert.Equal(t, []string{"list_dependabot_alerts"}, available)
+}
+
+func TestDynamicTools_EnableToolset_ExcludedTools(t *testing.T) {
+	// Build a registry with no toolsets enabled (dynamic mode) and write tools excluded
+	reg := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		WithExcludeTools([]string{"*_write"}).
+		Build()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0),
+		T:         translations.NullTranslationHelper,
+	}
+
+	tool := EnableToolset(reg)
+	handler := tool.Handler(deps)
+	result, err := handler(context.Background(), createDynamicRequest(map[string]any{"toolset": "issues"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var names []string
+	for _, st := range reg.AvailableTools(context.Background()) {
+		names = append(names, st.Tool.Name)
+	}
+	assert.Contains(t, names, "issue_read")
+	assert.NotContains(t, names, "issue_write")
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, fmt.Sprintf("with %d tools", len(names)))
+}
+
+func TestDynamicTools_CustomToolset(t *testing.T) {
+	// Build a registry with no toolsets enabled (dynamic mode) and a custom toolset
+	reg := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		WithCustomToolsets([]inventory.CustomToolset{{
+			ToolsetMetadata: inventory.ToolsetMetadata{ID: "triage", Description: "Triage issues"},
+			Tools:           []string{"issue_read", "search_issues", "label_write", "list_notifications"},
+		}}).
+		Build()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0),
+		T:         translations.NullTranslationHelper,
+	}
+
+	// The custom toolset is listed with the built-in ones
+	listTool := ListAvailableToolsets()
+	result, err := listTool.Handler(deps)(context.Background(), createDynamicRequest(map[string]any{}))
+	require.NoError(t, err)
+	var toolsets []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &toolsets))
+	assert.Contains(t, toolsets, map[string]string{
+		"name":              "triage",
+		"description":       "Triage issues",
+		"can_enable":        "true",
+		"currently_enabled": "false",
+	})
+
+	// Enabling it enables just its members, from several toolsets
+	enableTool := EnableToolset(reg)
+	result, err = enableTool.Handler(deps)(context.Background(), createDynamicRequest(map[string]any{"toolset": "triage"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Toolset triage enabled with 4 tools", result.Content[0].(*mcp.TextContent).Text)
+
+	var names []string
+	for _, st := range reg.AvailableTools(context.Background()) {
+		names = append(names, st.Tool.Name)
+	}
+	assert.ElementsMatch(t, []string{"issue_read", "search_issues", "label_write", "list_notifications"}, names)
+}
+
 func TestDynamicTools_EnableToolset_InvalidToolset(t *testing.T) {
 	// Build a registry with no toolsets enabled (dynamic mode)
 	reg := NewInventory(translations.NullTranslationHelper).
@@ -229,3 +448,77 @@ func TestDynamicTools_ToolsetsEnum(t *testing.T) {
 		}
 	}
 }
+
+func TestDynamicTools_Annotations(t *testing.T) {
+	reg := NewInventory(translations.NullTranslationHelper).Build()
+
+	// Dynamic tools only act on the server's own inventory, so they are closed-world and
+	// never destructive
+	for _, tool := range DynamicTools(reg) {
+		annotations := tool.Tool.Annotations
+		require.NotNil(t, annotations, "%s should have annotations", tool.Tool.Name)
+		require.NotNil(t, annotations.OpenWorldHint, "%s should set OpenWorldHint", tool.Tool.Name)
+		assert.False(t, *annotations.OpenWorldHint, "%s should not be open-world", tool.Tool.Name)
+		assert.False(t, tool.IsDestructive(), "%s should not be destructive", tool.Tool.Name)
+	}
+}
+
+func TestDynamicTools_ExplainTool(t *testing.T) {
+	// Build a registry with no toolsets enabled (dynamic mode)
+	reg := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		WithReadOnly(true).
+		Build()
+
+	deps := DynamicToolDependencies{
+		Server:    mcp.NewServer(&mcp.Implementation{Name: "test"}, nil),
+		Inventory: reg,
+		T:         translations.NullTranslationHelper,
+	}
+
+	tool := ExplainTool()
+	handler := tool.Handler(deps)
+
+	tests := []struct {
+		name           string
+		tool           string
+		expectedStage  inventory.FilterStage
+		expectedReason string
+	}{
+		{
+			name:           "read tool in a disabled toolset",
+			tool:           "get_me",
+			expectedStage:  inventory.FilterStageToolset,
+			expectedReason: "toolset context is not enabled",
+		},
+		{
+			name:           "write tool in read-only mode",
+			tool:           "create_gist",
+			expectedStage:  inventory.FilterStageReadOnly,
+			expectedReason: "write tool hidden in read-only mode",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createDynamicRequest(map[string]any{"tool": tc.tool}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var decisions []inventory.ToolDecision
+			textContent := result.Content[0].(*mcp.TextContent)
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &decisions))
+			require.Len(t, decisions, 1)
+			assert.Equal(t, tc.tool, decisions[0].Tool)
+			assert.False(t, decisions[0].Enabled)
+			assert.Equal(t, tc.expectedStage, decisions[0].Stage)
+			assert.Equal(t, tc.expectedReason, decisions[0].Reason)
+		})
+	}
+
+	// Unknown tools are an error result
+	result, err := handler(context.Background(), createDynamicRequest(map[string]any{"tool": "nonexistent"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "not found")
+}
@@ -25,8 +25,9 @@ func ListGists(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_gists",
 			Description: t("TOOL_LIST_GISTS_DESCRIPTION", "List gists for a user"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_GISTS", "List Gists"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_GISTS", "List Gists"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -112,8 +113,9 @@ func GetGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_gist",
 			Description: t("TOOL_GET_GIST_DESCRIPTION", "Get gist content of a particular gist, by gist ID"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_GIST", "Get Gist Content"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_GIST", "Get Gist Content"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -170,8 +172,10 @@ func CreateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "create_gist",
 			Description: t("TOOL_CREATE_GIST_DESCRIPTION", "Create a new gist"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CREATE_GIST", "Create Gist"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_CREATE_GIST", "Create Gist"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -273,8 +277,11 @@ func UpdateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "update_gist",
 			Description: t("TOOL_UPDATE_GIST_DESCRIPTION", "Update an existing gist"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_UPDATE_GIST", "Update Gist"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_UPDATE_GIST", "Update Gist"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -46,8 +46,9 @@ func GetRepositoryTree(t translations.TranslationHelperFunc) inventory.ServerToo
 			Name:        "get_repository_tree",
 			Description: t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "Get the tree structure (files and directories) of a GitHub repository at a specific ref or SHA"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -26,6 +26,9 @@ const (
 	PutUserStarredByOwnerByRepo    = "PUT /user/starred/{owner}/{repo}"
 	DeleteUserStarredByOwnerByRepo = "DELETE /user/starred/{owner}/{repo}"
 
+	// Rate limit endpoints
+	GetRateLimitEndpoint = "GET /rate_limit"
+
 	// Repository endpoints
 	GetReposByOwnerByRepo                = "GET /repos/{owner}/{repo}"
 	GetReposBranchesByOwnerByRepo        = "GET /repos/{owner}/{repo}/branches"
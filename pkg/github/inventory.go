@@ -10,9 +10,10 @@ import (
 // This function is stateless - no dependencies are captured.
 // Handlers are generated on-demand during registration via RegisterAll(ctx, server, deps).
 // The "default" keyword in WithToolsets will expand to toolsets marked with Default: true.
+// Tools that are not read-only accept the dry_run argument handled by dryrun.Middleware.
 func NewInventory(t translations.TranslationHelperFunc) *inventory.Builder {
 	return inventory.NewBuilder().
-		SetTools(AllTools(t)).
+		SetTools(withDryRunArgument(AllTools(t))).
 		SetResources(AllResources(t)).
 		SetPrompts(AllPrompts(t))
 }
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressReporter sends progress notifications for a tool call whose request carries a
+// progress token. A nil reporter does nothing, so tools can report progress unconditionally.
+type progressReporter struct {
+	session *mcp.ServerSession
+	token   any
+}
+
+// newProgressReporter returns a reporter for req, or nil if the client did not ask for progress.
+func newProgressReporter(req *mcp.CallToolRequest) *progressReporter {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	return &progressReporter{session: req.Session, token: token}
+}
+
+// report notifies the client that progress out of total units of work are done. A total of
+// zero means the total is unknown. Failing to deliver a notification does not fail the tool.
+func (p *progressReporter) report(ctx context.Context, progress, total float64, message string) {
+	if p == nil {
+		return
+	}
+	_ = p.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: p.token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+}
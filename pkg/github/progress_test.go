@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProgressReporter_NoToken(t *testing.T) {
+	assert.Nil(t, newProgressReporter(nil))
+	request := createMCPRequest(map[string]any{})
+	assert.Nil(t, newProgressReporter(&request))
+
+	// A nil reporter is safe to use
+	var progress *progressReporter
+	progress.report(context.Background(), 1, 2, "ignored")
+}
+
+func Test_GetJobLogs_ReportsProgress(t *testing.T) {
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("line 1\nline 2"))
+	}))
+	defer logServer.Close()
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposActionsRunsJobsByOwnerByRepoByRunID: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(&github.Jobs{
+				TotalCount: github.Ptr(2),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+				},
+			})
+		}),
+		GetReposActionsJobsLogsByOwnerByRepoByJobID: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", logServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	})
+	deps := BaseDeps{
+		Client:            github.NewClient(mockedClient),
+		ContentWindowSize: 5000,
+	}
+	params := &mcp.CallToolParams{
+		Meta: mcp.Meta{"progressToken": "logs"},
+		Name: "get_job_logs",
+		Arguments: map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"run_id":         456,
+			"failed_only":    true,
+			"return_content": true,
+		},
+	}
+	notifications := callToolWithProgress(t, GetJobLogs(translations.NullTranslationHelper), deps, params, 2)
+
+	for i, notification := range notifications {
+		assert.Equal(t, "logs", notification.ProgressToken)
+		assert.Equal(t, float64(i+1), notification.Progress)
+		assert.Equal(t, float64(2), notification.Total)
+	}
+	assert.Equal(t, "Retrieved logs for 1 of 2 failed jobs (13 bytes)", notifications[0].Message)
+	assert.Equal(t, "Retrieved logs for 2 of 2 failed jobs (26 bytes)", notifications[1].Message)
+}
+
+func Test_ProjectsList_ReportsProgress(t *testing.T) {
+	projects := []map[string]any{{"id": 1, "node_id": "NODE1", "title": "Roadmap"}}
+	tests := []struct {
+		name     string
+		args     map[string]any
+		link     string
+		messages []string
+	}{
+		{
+			name:     "first page",
+			link:     `<https://api.github.com/orgs/octo-org/projectsV2?after=next>; rel="next"`,
+			messages: []string{"Listing up to 50 projects", "Listed 1 projects, more pages are available"},
+		},
+		{
+			name:     "last page",
+			args:     map[string]any{"per_page": 10, "after": "abc"},
+			messages: []string{"Listing up to 10 projects after cursor abc", "Listed 1 projects, the last page"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetOrgsProjectsV2: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					if tc.link != "" {
+						w.Header().Set("Link", tc.link)
+					}
+					_ = json.NewEncoder(w).Encode(projects)
+				}),
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			args := map[string]any{
+				"method":     projectsMethodListProjects,
+				"owner":      "octo-org",
+				"owner_type": "org",
+			}
+			for key, value := range tc.args {
+				args[key] = value
+			}
+			params := &mcp.CallToolParams{
+				Meta:      mcp.Meta{"progressToken": "projects"},
+				Name:      "projects_list",
+				Arguments: args,
+			}
+			notifications := callToolWithProgress(t, ProjectsList(translations.NullTranslationHelper), deps, params, 2)
+
+			for i, notification := range notifications {
+				assert.Equal(t, "projects", notification.ProgressToken)
+				assert.Equal(t, float64(i), notification.Progress)
+				assert.Equal(t, float64(1), notification.Total)
+				assert.Equal(t, tc.messages[i], notification.Message)
+			}
+		})
+	}
+}
+
+// callToolWithProgress calls toolDef through a client session and returns the progress
+// notifications the client received, waiting until there are want of them.
+func callToolWithProgress(t *testing.T, toolDef inventory.ServerTool, deps ToolDependencies, params *mcp.CallToolParams, want int) []*mcp.ProgressNotificationParams {
+	t.Helper()
+	handler := toolDef.Handler(deps)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server.AddTool(&toolDef.Tool, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handler(ContextWithDeps(ctx, deps), req)
+	})
+
+	var mu sync.Mutex
+	var notifications []*mcp.ProgressNotificationParams
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			notifications = append(notifications, req.Params)
+		},
+	})
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	result, err := session.CallTool(ctx, params)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	// The client handles notifications asynchronously
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(notifications) == want
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return notifications
+}
@@ -50,8 +50,9 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_projects",
 			Description: t("TOOL_LIST_PROJECTS_DESCRIPTION", `List Projects for a user or organization`),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_PROJECTS_USER_TITLE", "List projects"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_PROJECTS_USER_TITLE", "List projects"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -86,7 +87,7 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 			},
 		},
 		[]scopes.Scope{scopes.ReadProject},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
@@ -127,6 +128,9 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Query:                         queryPtr,
 			}
 
+			progress := newProgressReporter(req)
+			reportProjectPageStart(ctx, progress, pagination, "projects")
+
 			if ownerType == "org" {
 				projects, resp, err = client.Projects.ListOrganizationProjects(ctx, owner, opts)
 			} else {
@@ -141,6 +145,7 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 				), nil, nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportProjectPageDone(ctx, progress, resp, len(projects), "projects")
 
 			for _, project := range projects {
 				minimalProjects = append(minimalProjects, *convertToMinimalProject(project))
@@ -170,8 +175,9 @@ func GetProject(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_project",
 			Description: t("TOOL_GET_PROJECT_DESCRIPTION", "Get Project for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_PROJECT_USER_TITLE", "Get project"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_PROJECT_USER_TITLE", "Get project"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -261,8 +267,9 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 			Name:        "list_project_fields",
 			Description: t("TOOL_LIST_PROJECT_FIELDS_DESCRIPTION", "List Project fields for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_PROJECT_FIELDS_USER_TITLE", "List project fields"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_PROJECT_FIELDS_USER_TITLE", "List project fields"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -297,7 +304,7 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 			},
 		},
 		[]scopes.Scope{scopes.ReadProject},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
@@ -331,6 +338,9 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 				ListProjectsPaginationOptions: pagination,
 			}
 
+			progress := newProgressReporter(req)
+			reportProjectPageStart(ctx, progress, pagination, "project fields")
+
 			if ownerType == "org" {
 				projectFields, resp, err = client.Projects.ListOrganizationProjectFields(ctx, owner, projectNumber, opts)
 			} else {
@@ -345,6 +355,7 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 				), nil, nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportProjectPageDone(ctx, progress, resp, len(projectFields), "project fields")
 
 			response := map[string]any{
 				"fields":   projectFields,
@@ -370,8 +381,9 @@ func GetProjectField(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "get_project_field",
 			Description: t("TOOL_GET_PROJECT_FIELD_DESCRIPTION", "Get Project field for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_PROJECT_FIELD_USER_TITLE", "Get project field"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_PROJECT_FIELD_USER_TITLE", "Get project field"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -465,8 +477,9 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "list_project_items",
 			Description: t("TOOL_LIST_PROJECT_ITEMS_DESCRIPTION", `Search project items with advanced filtering`),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -512,7 +525,7 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 			},
 		},
 		[]scopes.Scope{scopes.ReadProject},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
@@ -565,6 +578,9 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 			}
 
+			progress := newProgressReporter(req)
+			reportProjectPageStart(ctx, progress, pagination, "project items")
+
 			if ownerType == "org" {
 				projectItems, resp, err = client.Projects.ListOrganizationProjectItems(ctx, owner, projectNumber, opts)
 			} else {
@@ -579,6 +595,7 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 				), nil, nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportProjectPageDone(ctx, progress, resp, len(projectItems), "project items")
 
 			response := map[string]any{
 				"items":    projectItems,
@@ -604,8 +621,9 @@ func GetProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_project_item",
 			Description: t("TOOL_GET_PROJECT_ITEM_DESCRIPTION", "Get a specific Project item for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_PROJECT_ITEM_USER_TITLE", "Get project item"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_PROJECT_ITEM_USER_TITLE", "Get project item"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -713,8 +731,11 @@ func AddProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "add_project_item",
 			Description: t("TOOL_ADD_PROJECT_ITEM_DESCRIPTION", "Add a specific Project item for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_ADD_PROJECT_ITEM_USER_TITLE", "Add project item"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_ADD_PROJECT_ITEM_USER_TITLE", "Add project item"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -827,8 +848,11 @@ func UpdateProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 			Name:        "update_project_item",
 			Description: t("TOOL_UPDATE_PROJECT_ITEM_DESCRIPTION", "Update a specific Project item for a user or org"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_UPDATE_PROJECT_ITEM_USER_TITLE", "Update project item"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_UPDATE_PROJECT_ITEM_USER_TITLE", "Update project item"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -945,6 +969,8 @@ func DeleteProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 				Title:           t("TOOL_DELETE_PROJECT_ITEM_USER_TITLE", "Delete project item"),
 				ReadOnlyHint:    false,
 				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1035,8 +1061,9 @@ func ProjectsList(t translations.TranslationHelperFunc) inventory.ServerTool {
 Use this tool to list projects for a user or organization, or list project fields and items for a specific project.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_PROJECTS_LIST_USER_TITLE", "List GitHub Projects resources"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_PROJECTS_LIST_USER_TITLE", "List GitHub Projects resources"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1091,7 +1118,7 @@ Use this tool to list projects for a user or organization, or list project field
 			},
 		},
 		[]scopes.Scope{scopes.ReadProject},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			method, err := RequiredParam[string](args, "method")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1114,11 +1141,11 @@ Use this tool to list projects for a user or organization, or list project field
 
 			switch method {
 			case projectsMethodListProjects:
-				return listProjects(ctx, client, args, owner, ownerType)
+				return listProjects(ctx, client, args, owner, ownerType, newProgressReporter(req))
 			case projectsMethodListProjectFields:
-				return listProjectFields(ctx, client, args, owner, ownerType)
+				return listProjectFields(ctx, client, args, owner, ownerType, newProgressReporter(req))
 			case projectsMethodListProjectItems:
-				return listProjectItems(ctx, client, args, owner, ownerType)
+				return listProjectItems(ctx, client, args, owner, ownerType, newProgressReporter(req))
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -1138,8 +1165,9 @@ func ProjectsGet(t translations.TranslationHelperFunc) inventory.ServerTool {
 Use this tool to get details about individual projects, project fields, and project items by their unique IDs.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_PROJECTS_GET_USER_TITLE", "Get details of GitHub Projects resources"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_PROJECTS_GET_USER_TITLE", "Get details of GitHub Projects resources"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1251,6 +1279,8 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:           t("TOOL_PROJECTS_WRITE_USER_TITLE", "Modify GitHub Project items"),
 				ReadOnlyHint:    false,
 				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1363,7 +1393,7 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 
 // Helper functions for consolidated projects tools
 
-func listProjects(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
+func listProjects(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string, progress *progressReporter) (*mcp.CallToolResult, any, error) {
 	queryStr, err := OptionalParam[string](args, "query")
 	if err != nil {
 		return utils.NewToolResultError(err.Error()), nil, nil
@@ -1388,6 +1418,8 @@ func listProjects(ctx context.Context, client *github.Client, args map[string]an
 		Query:                         queryPtr,
 	}
 
+	reportProjectPageStart(ctx, progress, pagination, "projects")
+
 	if ownerType == "org" {
 		projects, resp, err = client.Projects.ListOrganizationProjects(ctx, owner, opts)
 	} else {
@@ -1402,6 +1434,7 @@ func listProjects(ctx context.Context, client *github.Client, args map[string]an
 		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
+	reportProjectPageDone(ctx, progress, resp, len(projects), "projects")
 
 	for _, project := range projects {
 		minimalProjects = append(minimalProjects, *convertToMinimalProject(project))
@@ -1420,7 +1453,7 @@ func listProjects(ctx context.Context, client *github.Client, args map[string]an
 	return utils.NewToolResultText(string(r)), nil, nil
 }
 
-func listProjectFields(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
+func listProjectFields(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string, progress *progressReporter) (*mcp.CallToolResult, any, error) {
 	projectNumber, err := RequiredInt(args, "project_number")
 	if err != nil {
 		return utils.NewToolResultError(err.Error()), nil, nil
@@ -1438,6 +1471,8 @@ func listProjectFields(ctx context.Context, client *github.Client, args map[stri
 		ListProjectsPaginationOptions: pagination,
 	}
 
+	reportProjectPageStart(ctx, progress, pagination, "project fields")
+
 	if ownerType == "org" {
 		projectFields, resp, err = client.Projects.ListOrganizationProjectFields(ctx, owner, projectNumber, opts)
 	} else {
@@ -1452,6 +1487,7 @@ func listProjectFields(ctx context.Context, client *github.Client, args map[stri
 		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
+	reportProjectPageDone(ctx, progress, resp, len(projectFields), "project fields")
 
 	response := map[string]any{
 		"fields":   projectFields,
@@ -1466,7 +1502,7 @@ func listProjectFields(ctx context.Context, client *github.Client, args map[stri
 	return utils.NewToolResultText(string(r)), nil, nil
 }
 
-func listProjectItems(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
+func listProjectItems(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string, progress *progressReporter) (*mcp.CallToolResult, any, error) {
 	projectNumber, err := RequiredInt(args, "project_number")
 	if err != nil {
 		return utils.NewToolResultError(err.Error()), nil, nil
@@ -1503,6 +1539,8 @@ func listProjectItems(ctx context.Context, client *github.Client, args map[strin
 		},
 	}
 
+	reportProjectPageStart(ctx, progress, pagination, "project items")
+
 	if ownerType == "org" {
 		projectItems, resp, err = client.Projects.ListOrganizationProjectItems(ctx, owner, projectNumber, opts)
 	} else {
@@ -1517,6 +1555,7 @@ func listProjectItems(ctx context.Context, client *github.Client, args map[strin
 		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
+	reportProjectPageDone(ctx, progress, resp, len(projectItems), "project items")
 
 	response := map[string]any{
 		"items":    projectItems,
@@ -1834,6 +1873,29 @@ func buildPageInfo(resp *github.Response) pageInfo {
 	}
 }
 
+// reportProjectPageStart reports that a page of resources is being listed, with the page size
+// and cursor. Listing tools fetch one page per call, so the call is done when the page is.
+func reportProjectPageStart(ctx context.Context, progress *progressReporter, pagination github.ListProjectsPaginationOptions, resource string) {
+	message := fmt.Sprintf("Listing up to %d %s", pagination.GetPerPage(), resource)
+	switch {
+	case pagination.GetAfter() != "":
+		message += fmt.Sprintf(" after cursor %s", pagination.GetAfter())
+	case pagination.GetBefore() != "":
+		message += fmt.Sprintf(" before cursor %s", pagination.GetBefore())
+	}
+	progress.report(ctx, 0, 1, message)
+}
+
+// reportProjectPageDone reports that a page of count resources was listed, and whether more
+// pages are available.
+func reportProjectPageDone(ctx context.Context, progress *progressReporter, resp *github.Response, count int, resource string) {
+	message := fmt.Sprintf("Listed %d %s, the last page", count, resource)
+	if resp.After != "" {
+		message = fmt.Sprintf("Listed %d %s, more pages are available", count, resource)
+	}
+	progress.report(ctx, 1, 1, message)
+}
+
 func extractPaginationOptionsFromArgs(args map[string]any) (github.ListProjectsPaginationOptions, error) {
 	perPage, err := OptionalIntParamWithDefault(args, "per_page", MaxProjectsPerPage)
 	if err != nil {
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/repopolicy"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// repoSearchTools are the search tools whose queries match content in repositories, and so
+// are limited to the repositories a policy allows with repo: and org: qualifiers.
+var repoSearchTools = map[string]bool{
+	"search_code":          true,
+	"search_issues":        true,
+	"search_pull_requests": true,
+	"search_repositories":  true,
+}
+
+// repoFreeTools are the tools that do not act on repositories, and so are allowed by any
+// policy without naming one. Calls of other tools that name no repository, such as listing
+// notifications across repositories or acting on a notification thread by its ID, may reach
+// any repository, and are refused by policies that restrict access.
+var repoFreeTools = map[string]bool{
+	"create_gist":                     true,
+	"get_gist":                        true,
+	"get_global_security_advisory":    true,
+	"get_me":                          true,
+	"get_rate_limit":                  true,
+	"get_teams":                       true,
+	"list_gists":                      true,
+	"list_global_security_advisories": true,
+	"search_orgs":                     true,
+	"search_users":                    true,
+	"update_gist":                     true,
+}
+
+// repoTarget is a repository a call acts on, or every repository of owner if repo is empty.
+type repoTarget struct {
+	owner  string
+	repo   string
+	access repopolicy.Access
+}
+
+func (t repoTarget) check(policy *repopolicy.Policy) error {
+	if t.repo == "" {
+		return policy.CheckOwner(t.owner, t.access)
+	}
+	return policy.CheckRepo(t.owner, t.repo, t.access)
+}
+
+// RepoPolicyMiddleware returns receiving middleware that refuses calls of tools in inv, and
+// reads of repo:// resources, for repositories outside policy. Tools are checked through
+// their owner, repo and org arguments, needing write access unless they are read-only.
+// Queries of repository search tools are checked through their repo:, org: and user:
+// qualifiers; queries without any are limited to the allowed repositories by adding
+// qualifiers, or refused if the policy cannot be written as qualifiers. Queries with OR or
+// parentheses are refused if the policy restricts their access. Other calls that name
+// no repository are refused if the policy restricts their access, unless the tool does not
+// act on repositories.
+func RepoPolicyMiddleware(policy *repopolicy.Policy, inv *inventory.Inventory) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch r := req.(type) {
+			case *mcp.CallToolRequest:
+				if r.Params == nil {
+					break
+				}
+				tool, _, err := inv.FindToolByName(r.Params.Name)
+				if err != nil {
+					break
+				}
+				if refusal := checkToolCall(policy, r.Params, tool.IsReadOnly()); refusal != nil {
+					return refusal.Result(r.Params.Name), nil
+				}
+			case *mcp.ReadResourceRequest:
+				if r.Params == nil {
+					break
+				}
+				if owner, repo, ok := resourceRepo(r.Params.URI); ok {
+					if err := policy.CheckRepo(owner, repo, repopolicy.AccessRead); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// checkToolCall checks the repositories a tool call acts on against policy, returning the
+// refusal if any is outside it. The query of a search without qualifiers is rewritten in
+// params to limit it to the allowed repositories.
+func checkToolCall(policy *repopolicy.Policy, params *mcp.CallToolParamsRaw, readOnly bool) *repopolicy.Error {
+	access := repopolicy.AccessWrite
+	if readOnly {
+		access = repopolicy.AccessRead
+	}
+	var args map[string]any
+	if len(params.Arguments) > 0 {
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return &repopolicy.Error{
+				Reason:  repopolicy.ReasonPolicy,
+				Access:  access,
+				Message: fmt.Sprintf("the repository policy could not check this call of %s, as its arguments are not a JSON object", params.Name),
+			}
+		}
+	}
+	// Missing arguments are checked as no arguments, as the SDK calls the tool with them
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	targets := toolRepoTargets(params.Name, args, access)
+	if query, ok := args["query"].(string); ok && repoSearchTools[params.Name] {
+		// Qualifiers only limit the terms they are combined with, so a query with OR or
+		// parentheses may reach any repository whatever qualifiers it has
+		if policy.Restricts(access) && hasBooleanGroups(query) {
+			return &repopolicy.Error{
+				Reason:  repopolicy.ReasonPolicy,
+				Access:  access,
+				Message: "the repository policy does not allow search queries with OR or parentheses, as they may reach repositories outside the policy",
+			}
+		}
+		searchTargets := searchRepoTargets(query, access)
+		// Searches with owner and repo arguments are limited to that repository by the tool
+		scoped := len(searchTargets) > 0 || (stringArg(args, "owner") != "" && stringArg(args, "repo") != "")
+		if !scoped {
+			// The qualifiers of the policy may still reach denied repositories, such as
+			// org: qualifiers of owners with some repositories denied
+			qualifiers, ok := policy.SearchQualifiers(access)
+			scopedQuery := strings.Join(append(qualifiers, query), " ")
+			if ok && checkRepoTargets(policy, searchRepoTargets(scopedQuery, access)) != nil {
+				ok = false
+			}
+			if !ok {
+				return &repopolicy.Error{
+					Reason:  repopolicy.ReasonPolicy,
+					Access:  access,
+					Message: "the repository policy only allows searches limited to allowed repositories; add repo:owner/name or org:owner qualifiers to the query",
+				}
+			}
+			if len(qualifiers) > 0 {
+				args["query"] = scopedQuery
+				if data, err := json.Marshal(args); err == nil {
+					params.Arguments = data
+				}
+			}
+		}
+		targets = append(targets, searchTargets...)
+	} else if len(targets) == 0 && !repoFreeTools[params.Name] && policy.Restricts(access) {
+		return &repopolicy.Error{
+			Reason:  repopolicy.ReasonPolicy,
+			Access:  access,
+			Message: fmt.Sprintf("the repository policy only allows calls that name an allowed repository, and this call of %s may reach any repository; pass the owner and repo arguments where the tool takes them", params.Name),
+		}
+	}
+
+	return checkRepoTargets(policy, targets)
+}
+
+// checkRepoTargets returns the refusal of the first target outside policy, if any.
+func checkRepoTargets(policy *repopolicy.Policy, targets []repoTarget) *repopolicy.Error {
+	for _, target := range targets {
+		if err := target.check(policy); err != nil {
+			refusal, _ := err.(*repopolicy.Error)
+			return refusal
+		}
+	}
+	return nil
+}
+
+// toolRepoTargets returns the repositories a tool call names in its arguments.
+func toolRepoTargets(tool string, args map[string]any, access repopolicy.Access) []repoTarget {
+	owner := stringArg(args, "owner")
+	if owner == "" {
+		owner = stringArg(args, "org")
+	}
+	repo := stringArg(args, "repo")
+	organization := stringArg(args, "organization")
+
+	switch tool {
+	case "create_repository":
+		// Repositories created without an organization belong to the user
+		if organization != "" {
+			return []repoTarget{{owner: organization, repo: stringArg(args, "name"), access: access}}
+		}
+		return nil
+	case "fork_repository":
+		targets := []repoTarget{{owner: owner, repo: repo, access: repopolicy.AccessRead}}
+		if organization != "" {
+			targets = append(targets, repoTarget{owner: organization, repo: repo, access: access})
+		}
+		return targets
+	}
+	if owner == "" {
+		return nil
+	}
+	return []repoTarget{{owner: owner, repo: repo, access: access}}
+}
+
+// searchRepoTargets returns the repositories and owners a search query is limited to by its
+// repo:, org: and user: qualifiers.
+func searchRepoTargets(query string, access repopolicy.Access) []repoTarget {
+	var targets []repoTarget
+	for _, value := range filterValues(query, "repo") {
+		owner, repo, _ := strings.Cut(value, "/")
+		targets = append(targets, repoTarget{owner: owner, repo: repo, access: access})
+	}
+	for _, filterType := range []string{"org", "user"} {
+		for _, owner := range filterValues(query, filterType) {
+			targets = append(targets, repoTarget{owner: owner, access: access})
+		}
+	}
+	return targets
+}
+
+// quotedTerms matches the quoted terms of a search query, whose OR and parentheses are text.
+var quotedTerms = regexp.MustCompile(`"[^"]*"`)
+
+// hasBooleanGroups reports whether a search query combines terms with OR or groups them with
+// parentheses.
+func hasBooleanGroups(query string) bool {
+	query = quotedTerms.ReplaceAllString(query, "")
+	if strings.ContainsAny(query, "()") {
+		return true
+	}
+	for _, term := range strings.Fields(query) {
+		if term == "OR" {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceRepo returns the repository of a repo:// resource URI.
+func resourceRepo(uri string) (owner, repo string, ok bool) {
+	rest, ok := strings.CutPrefix(uri, "repo://")
+	if !ok {
+		return "", "", false
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func stringArg(args map[string]any, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
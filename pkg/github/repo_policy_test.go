@@ -0,0 +1,269 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/repopolicy"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoPolicyMiddleware(t *testing.T) {
+	policy, err := repopolicy.Parse(
+		[]string{"my-org/*", "octocat/hello-world", "write:my-org/sandbox"},
+		[]string{"my-org/secrets"},
+	)
+	require.NoError(t, err)
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+		// refused is the substring of the refusal, empty if the call is allowed
+		refused string
+		// query is the query the tool is called with, if rewritten
+		query string
+	}{
+		{
+			name: "allowed read",
+			tool: "get_file_contents",
+			args: map[string]any{"owner": "my-org", "repo": "api", "path": "README.md"},
+		},
+		{
+			name:    "repository not in allow-list",
+			tool:    "get_file_contents",
+			args:    map[string]any{"owner": "other", "repo": "api"},
+			refused: "does not allow read access to other/api",
+		},
+		{
+			name:    "denied repository",
+			tool:    "issue_read",
+			args:    map[string]any{"owner": "my-org", "repo": "secrets", "method": "get", "issue_number": 1},
+			refused: "denies read access to my-org/secrets",
+		},
+		{
+			name:    "write outside write allow-list",
+			tool:    "create_branch",
+			args:    map[string]any{"owner": "my-org", "repo": "api", "branch": "x"},
+			refused: "does not allow write access to my-org/api",
+		},
+		{
+			name: "write inside write allow-list",
+			tool: "create_branch",
+			args: map[string]any{"owner": "my-org", "repo": "sandbox", "branch": "x"},
+		},
+		{
+			name:    "organization-wide call with a denied repository",
+			tool:    "list_org_repository_security_advisories",
+			args:    map[string]any{"org": "my-org"},
+			refused: "calls across all its repositories are refused",
+		},
+		{
+			name:    "fork into an organization",
+			tool:    "fork_repository",
+			args:    map[string]any{"owner": "octocat", "repo": "hello-world", "organization": "elsewhere"},
+			refused: "does not allow write access to elsewhere/hello-world",
+		},
+		{
+			name: "search with an allowed qualifier",
+			tool: "search_code",
+			args: map[string]any{"query": "func main repo:my-org/api"},
+		},
+		{
+			name:    "search with a denied qualifier",
+			tool:    "search_issues",
+			args:    map[string]any{"query": "bug repo:my-org/secrets"},
+			refused: "denies read access to my-org/secrets",
+		},
+		{
+			name:    "search of a partly denied organization",
+			tool:    "search_code",
+			args:    map[string]any{"query": "password org:my-org"},
+			refused: "calls across all its repositories are refused",
+		},
+		{
+			name:    "search without qualifiers",
+			tool:    "search_repositories",
+			args:    map[string]any{"query": "language:go"},
+			refused: "add repo:owner/name or org:owner qualifiers",
+		},
+		{
+			name: "search scoped by owner and repo arguments",
+			tool: "search_issues",
+			args: map[string]any{"query": "bug", "owner": "octocat", "repo": "hello-world"},
+		},
+		{
+			name: "tool without repository",
+			tool: "get_me",
+			args: map[string]any{},
+		},
+		{
+			name: "notifications of an allowed repository",
+			tool: "list_notifications",
+			args: map[string]any{"owner": "my-org", "repo": "api"},
+		},
+		{
+			name:    "notifications across repositories",
+			tool:    "list_notifications",
+			args:    map[string]any{},
+			refused: "may reach any repository",
+		},
+		{
+			name:    "notifications without arguments",
+			tool:    "list_notifications",
+			refused: "may reach any repository",
+		},
+		{
+			name:    "search with OR",
+			tool:    "search_issues",
+			args:    map[string]any{"query": "repo:my-org/api OR label:bug"},
+			refused: "does not allow search queries with OR or parentheses",
+		},
+		{
+			name:    "search with parentheses",
+			tool:    "search_code",
+			args:    map[string]any{"query": "repo:my-org/api (label:bug)"},
+			refused: "does not allow search queries with OR or parentheses",
+		},
+		{
+			name: "search with quoted OR",
+			tool: "search_code",
+			args: map[string]any{"query": `"this OR that (maybe)" repo:my-org/api`},
+		},
+		{
+			name:    "notification thread by ID",
+			tool:    "dismiss_notification",
+			args:    map[string]any{"threadID": "1", "state": "read"},
+			refused: "may reach any repository",
+		},
+		{
+			name:    "write across repositories",
+			tool:    "mark_all_notifications_read",
+			args:    map[string]any{},
+			refused: "may reach any repository",
+		},
+		{
+			name:    "repository created for the user",
+			tool:    "create_repository",
+			args:    map[string]any{"name": "new"},
+			refused: "may reach any repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var called *mcp.CallToolRequest
+			next := func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+				called = req.(*mcp.CallToolRequest)
+				return &mcp.CallToolResult{}, nil
+			}
+			// Calls without args are sent without arguments
+			var rawArgs json.RawMessage
+			if tc.args != nil {
+				var err error
+				rawArgs, err = json.Marshal(tc.args)
+				require.NoError(t, err)
+			}
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tc.tool, Arguments: rawArgs}}
+
+			result, err := RepoPolicyMiddleware(policy, inv)(next)(context.Background(), "tools/call", req)
+			require.NoError(t, err)
+
+			if tc.refused == "" {
+				require.NotNil(t, called, "expected the call to be allowed")
+				return
+			}
+			assert.Nil(t, called, "expected the call to be refused")
+			callResult := result.(*mcp.CallToolResult)
+			assert.True(t, callResult.IsError)
+			assert.Contains(t, callResult.Content[0].(*mcp.TextContent).Text, tc.refused)
+			assert.IsType(t, &repopolicy.Error{}, callResult.StructuredContent)
+		})
+	}
+}
+
+func TestRepoPolicyMiddleware_UnscopedCallsWithoutRestriction(t *testing.T) {
+	// Write rules do not restrict reads, so reads across repositories are allowed
+	policy, err := repopolicy.Parse([]string{"write:my-org/*"}, nil)
+	require.NoError(t, err)
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	called := false
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		called = true
+		return &mcp.CallToolResult{}, nil
+	}
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_notifications", Arguments: json.RawMessage(`{}`)}}
+
+	_, err = RepoPolicyMiddleware(policy, inv)(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestRepoPolicyMiddleware_MalformedArguments(t *testing.T) {
+	policy, err := repopolicy.Parse([]string{"my-org/*"}, nil)
+	require.NoError(t, err)
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	for _, arguments := range []string{`{"owner":`, `[]`, `"my-org/api"`} {
+		called := false
+		next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			called = true
+			return &mcp.CallToolResult{}, nil
+		}
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_file_contents", Arguments: json.RawMessage(arguments)}}
+
+		result, err := RepoPolicyMiddleware(policy, inv)(next)(context.Background(), "tools/call", req)
+		require.NoError(t, err)
+		assert.False(t, called, arguments)
+		assert.True(t, result.(*mcp.CallToolResult).IsError, arguments)
+	}
+}
+
+func TestRepoFreeTools(t *testing.T) {
+	tools := make(map[string]bool)
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		tools[tool.Tool.Name] = true
+	}
+	for name := range repoFreeTools {
+		assert.True(t, tools[name], "repoFreeTools lists unknown tool %q", name)
+	}
+}
+
+func TestRepoPolicyMiddleware_ScopesSearches(t *testing.T) {
+	policy, err := repopolicy.Parse([]string{"my-org/*", "octocat/hello-world"}, nil)
+	require.NoError(t, err)
+	inv := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"all"}).Build()
+
+	var args map[string]any
+	next := func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		require.NoError(t, json.Unmarshal(req.(*mcp.CallToolRequest).Params.Arguments, &args))
+		return &mcp.CallToolResult{}, nil
+	}
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "search_code", Arguments: json.RawMessage(`{"query":"func main"}`)}}
+
+	_, err = RepoPolicyMiddleware(policy, inv)(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+	assert.Equal(t, "org:my-org repo:octocat/hello-world func main", args["query"])
+}
+
+func TestRepoPolicyMiddleware_Resources(t *testing.T) {
+	policy, err := repopolicy.Parse([]string{"my-org/*"}, nil)
+	require.NoError(t, err)
+	inv := NewInventory(translations.NullTranslationHelper).Build()
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return &mcp.ReadResourceResult{}, nil
+	}
+	handler := RepoPolicyMiddleware(policy, inv)(next)
+
+	_, err = handler(context.Background(), "resources/read", &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "repo://my-org/api/contents/README.md"}})
+	assert.NoError(t, err)
+
+	_, err = handler(context.Background(), "resources/read", &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "repo://other/api/contents/README.md"}})
+	assert.ErrorContains(t, err, "does not allow read access to other/api")
+}
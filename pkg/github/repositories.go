@@ -27,8 +27,9 @@ func GetCommit(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_commit",
 			Description: t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -125,8 +126,9 @@ func ListCommits(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_commits",
 			Description: t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository. Returns at least 30 results per page by default, but can return more if specified using the perPage parameter (up to 100)."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -233,8 +235,9 @@ func ListBranches(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_branches",
 			Description: t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -327,8 +330,10 @@ git ls-tree HEAD <path to file>
 If the SHA is not provided, the tool will attempt to acquire it by fetching the current file contents from the repository, which may lead to rewriting latest committed changes if the file has changed since last retrieval.
 `),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -519,8 +524,11 @@ func CreateRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "create_repository",
 			Description: t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account or specified organization"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -625,8 +633,9 @@ func GetFileContents(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "get_file_contents",
 			Description: t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -818,8 +827,11 @@ func ForkRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Description: t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization"),
 			Icons:       octicons.Icons("repo-forked"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -918,7 +930,9 @@ func DeleteFile(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Annotations: &mcp.ToolAnnotations{
 				Title:           t("TOOL_DELETE_FILE_USER_TITLE", "Delete file"),
 				ReadOnlyHint:    false,
-				DestructiveHint: github.Ptr(true),
+				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1101,8 +1115,11 @@ func CreateBranch(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "create_branch",
 			Description: t("TOOL_CREATE_BRANCH_DESCRIPTION", "Create a new branch in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CREATE_BRANCH_USER_TITLE", "Create branch"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_CREATE_BRANCH_USER_TITLE", "Create branch"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1214,8 +1231,10 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "push_files",
 			Description: t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1259,7 +1278,7 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 			},
 		},
 		[]scopes.Scope{scopes.Repo},
-		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1288,6 +1307,11 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			// Pushing takes four steps: resolving the branch, creating the tree, creating the
+			// commit and updating the branch
+			const pushSteps = 4
+			progress := newProgressReporter(req)
+
 			// Get the reference for the branch
 			var repositoryIsEmpty bool
 			var branchNotFound bool
@@ -1355,9 +1379,11 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 
 				baseCommit = base
 			}
+			progress.report(ctx, 1, pushSteps, fmt.Sprintf("Resolved branch %s", branch))
 
 			// Create tree entries for all files (or remaining files if empty repo)
 			var entries []*github.TreeEntry
+			var totalBytes int
 
 			for _, file := range filesObj {
 				fileMap, ok := file.(map[string]interface{})
@@ -1375,6 +1401,8 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return utils.NewToolResultError("each file must have content"), nil, nil
 				}
 
+				totalBytes += len(content)
+
 				// Create a tree entry for the file
 				entries = append(entries, &github.TreeEntry{
 					Path:    github.Ptr(path),
@@ -1397,6 +1425,8 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				defer func() { _ = resp.Body.Close() }()
 			}
 
+			progress.report(ctx, 2, pushSteps, fmt.Sprintf("Uploaded %d files (%d bytes)", len(entries), totalBytes))
+
 			// Create a new commit (baseCommit always has a value now)
 			commit := github.Commit{
 				Message: github.Ptr(message),
@@ -1415,6 +1445,8 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				defer func() { _ = resp.Body.Close() }()
 			}
 
+			progress.report(ctx, 3, pushSteps, fmt.Sprintf("Created commit %s", newCommit.GetSHA()))
+
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
 			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, *ref.Ref, github.UpdateRef{
@@ -1429,6 +1461,7 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				), nil, nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.report(ctx, 4, pushSteps, fmt.Sprintf("Updated branch %s", branch))
 
 			r, err := json.Marshal(updatedRef)
 			if err != nil {
@@ -1448,8 +1481,9 @@ func ListTags(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_tags",
 			Description: t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -1527,8 +1561,9 @@ func GetTag(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "get_tag",
 			Description: t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1625,8 +1660,9 @@ func ListReleases(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "list_releases",
 			Description: t("TOOL_LIST_RELEASES_DESCRIPTION", "List releases in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -1700,8 +1736,9 @@ func GetLatestRelease(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "get_latest_release",
 			Description: t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest release in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1765,8 +1802,9 @@ func GetReleaseByTag(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "get_release_by_tag",
 			Description: t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get a specific release by its tag name in a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -1843,8 +1881,9 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 			Name:        "list_starred_repositories",
 			Description: t("TOOL_LIST_STARRED_REPOSITORIES_DESCRIPTION", "List starred repositories"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: WithPagination(&jsonschema.Schema{
 				Type: "object",
@@ -1976,8 +2015,11 @@ func StarRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Description: t("TOOL_STAR_REPOSITORY_DESCRIPTION", "Star a GitHub repository"),
 			Icons:       octicons.Icons("star-fill"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_STAR_REPOSITORY_USER_TITLE", "Star repository"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_STAR_REPOSITORY_USER_TITLE", "Star repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -2041,8 +2083,11 @@ func UnstarRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 			Name:        "unstar_repository",
 			Description: t("TOOL_UNSTAR_REPOSITORY_DESCRIPTION", "Unstar a GitHub repository"),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_UNSTAR_REPOSITORY_USER_TITLE", "Unstar repository"),
-				ReadOnlyHint: false,
+				Title:           t("TOOL_UNSTAR_REPOSITORY_USER_TITLE", "Unstar repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   jsonschema.Ptr(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -103,8 +103,14 @@ func GetRepositoryResourcePrContent(t translations.TranslationHelperFunc) invent
 // repositoryResourceContentsHandlerFunc returns a ResourceHandlerFunc that creates handlers on-demand.
 func repositoryResourceContentsHandlerFunc(resourceURITemplate *uritemplate.Template) inventory.ResourceHandlerFunc {
 	return func(deps any) mcp.ResourceHandler {
-		d := deps.(ToolDependencies)
-		return RepositoryResourceContentsHandler(d, resourceURITemplate)
+		return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			// Prefer request-scoped deps, which servers building clients per request inject via ContextWithDeps
+			d, ok := DepsFromContext(ctx)
+			if !ok {
+				d = deps.(ToolDependencies)
+			}
+			return RepositoryResourceContentsHandler(d, resourceURITemplate)(ctx, request)
+		}
 	}
 }
 
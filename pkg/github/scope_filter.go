@@ -30,6 +30,9 @@ func onlyRequiresRepoScopes(acceptedScopes []string) bool {
 	return true
 }
 
+// ToolScopeFilterName names the token scope filter in inventory tool decisions.
+const ToolScopeFilterName = "token_scopes"
+
 // CreateToolScopeFilter creates an inventory.ToolFilter that filters tools
 // based on the token's OAuth scopes.
 //
@@ -52,8 +52,9 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 			Name:        "search_repositories",
 			Description: t("TOOL_SEARCH_REPOSITORIES_DESCRIPTION", "Find GitHub repositories by name, description, readme, topics, or other metadata. Perfect for discovering projects, finding examples, or locating specific repositories across GitHub."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: schema,
 		},
@@ -195,8 +196,9 @@ func SearchCode(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "search_code",
 			Description: t("TOOL_SEARCH_CODE_DESCRIPTION", "Fast and precise code search across ALL GitHub repositories using GitHub's native search engine. Best for finding exact symbols, functions, classes, or specific code patterns."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: schema,
 		},
@@ -377,8 +379,9 @@ func SearchUsers(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "search_users",
 			Description: t("TOOL_SEARCH_USERS_DESCRIPTION", "Find GitHub users by username, real name, or other profile information. Useful for locating developers, contributors, or team members."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: schema,
 		},
@@ -419,8 +422,9 @@ func SearchOrgs(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name:        "search_orgs",
 			Description: t("TOOL_SEARCH_ORGS_DESCRIPTION", "Find GitHub organizations by name, location, or other organization metadata. Ideal for discovering companies, open source foundations, or teams."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_SEARCH_ORGS_USER_TITLE", "Search organizations"),
-				ReadOnlyHint: true,
+				Title:         t("TOOL_SEARCH_ORGS_USER_TITLE", "Search organizations"),
+				ReadOnlyHint:  true,
+				OpenWorldHint: jsonschema.Ptr(true),
 			},
 			InputSchema: schema,
 		},
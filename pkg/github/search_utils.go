@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -29,6 +30,17 @@ func hasSpecificFilter(query, filterType, filterValue string) bool {
 	return matched
 }
 
+// filterValues returns the values of the filterType qualifiers in query, such as the
+// owner/repo of each repo: qualifier. Negated qualifiers, which exclude results, are skipped.
+func filterValues(query, filterType string) []string {
+	pattern := regexp.MustCompile(fmt.Sprintf(`(^|[\s(])%s:("[^"]*"|[^\s)]+)`, regexp.QuoteMeta(filterType)))
+	var values []string
+	for _, match := range pattern.FindAllStringSubmatch(query, -1) {
+		values = append(values, strings.Trim(match[2], `"`))
+	}
+	return values
+}
+
 func hasRepoFilter(query string) bool {
 	return hasFilter(query, "repo")
 }
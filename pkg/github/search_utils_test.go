@@ -350,3 +350,49 @@ func Test_hasTypeFilter(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		filterType string
+		expected   []string
+	}{
+		{
+			name:       "several repo filters",
+			query:      "repo:github/first is:issue repo:octocat/second",
+			filterType: "repo",
+			expected:   []string{"github/first", "octocat/second"},
+		},
+		{
+			name:       "filter in parentheses",
+			query:      "is:issue (org:github OR org:octocat)",
+			filterType: "org",
+			expected:   []string{"github", "octocat"},
+		},
+		{
+			name:       "quoted value",
+			query:      `repo:"github/github-mcp-server" bug`,
+			filterType: "repo",
+			expected:   []string{"github/github-mcp-server"},
+		},
+		{
+			name:       "negated filter skipped",
+			query:      "bug -repo:github/secret",
+			filterType: "repo",
+			expected:   nil,
+		},
+		{
+			name:       "filter name inside another word",
+			query:      "myrepo:github/first this repo: is important",
+			filterType: "repo",
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filterValues(tt.query, tt.filterType))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v79/github"
@@ -25,6 +26,7 @@ type stubDeps struct {
 	rawClientFn func(context.Context) (*raw.Client, error)
 
 	repoAccessCache   *lockdown.RepoAccessCache
+	rateLimits        *ratelimit.Tracker
 	t                 translations.TranslationHelperFunc
 	flags             FeatureFlags
 	contentWindowSize int
@@ -55,6 +57,7 @@ func (s stubDeps) GetRepoAccessCache() *lockdown.RepoAccessCache { return s.repo
 func (s stubDeps) GetT() translations.TranslationHelperFunc      { return s.t }
 func (s stubDeps) GetFlags() FeatureFlags                        { return s.flags }
 func (s stubDeps) GetContentWindowSize() int                     { return s.contentWindowSize }
+func (s stubDeps) GetRateLimits() *ratelimit.Tracker             { return s.rateLimits }
 
 // Helper functions to create stub client functions for error testing
 func stubClientFnFromHTTP(httpClient *http.Client) func(context.Context) (*github.Client, error) {
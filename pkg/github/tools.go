@@ -2,9 +2,11 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v79/github"
 	"github.com/shurcooL/githubv4"
@@ -155,6 +157,7 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		GetMe(t),
 		GetTeams(t),
 		GetTeamMembers(t),
+		GetRateLimit(t),
 
 		// Repository tools
 		SearchRepositories(t),
@@ -462,3 +465,39 @@ func RemoteOnlyToolsets() []inventory.ToolsetMetadata {
 		ToolsetMetadataSupportSearch,
 	}
 }
+
+// ValidateCustomToolsets checks that custom toolsets have usable IDs that are not taken by
+// built-in toolsets or keywords, list at least one tool, and use an embedded Octicon.
+func ValidateCustomToolsets(toolsets []inventory.CustomToolset) error {
+	reserved := map[inventory.ToolsetID]bool{
+		ToolsetMetadataAll.ID:     true,
+		ToolsetMetadataDefault.ID: true,
+		ToolsetMetadataDynamic.ID: true,
+	}
+	for _, id := range NewInventory(stubTranslator).Build().ToolsetIDs() {
+		reserved[id] = true
+	}
+	for _, ts := range RemoteOnlyToolsets() {
+		reserved[ts.ID] = true
+	}
+
+	seen := make(map[inventory.ToolsetID]bool, len(toolsets))
+	for i, ts := range toolsets {
+		switch {
+		case ts.ID == "":
+			return fmt.Errorf("custom toolset %d has no id", i+1)
+		case strings.ContainsAny(string(ts.ID), " ,*?["):
+			return fmt.Errorf("custom toolset id %q must not contain spaces, commas or glob characters", ts.ID)
+		case reserved[ts.ID]:
+			return fmt.Errorf("custom toolset id %q is already used by a built-in toolset", ts.ID)
+		case seen[ts.ID]:
+			return fmt.Errorf("custom toolset id %q is declared more than once", ts.ID)
+		case len(ts.Tools) == 0:
+			return fmt.Errorf("custom toolset %q lists no tools", ts.ID)
+		case ts.Icon != "" && octicons.DataURI(ts.Icon, octicons.ThemeLight) == "":
+			return fmt.Errorf("custom toolset %q uses icon %q, which is not an embedded octicon", ts.ID, ts.Icon)
+		}
+		seen[ts.ID] = true
+	}
+	return nil
+}
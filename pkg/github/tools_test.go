@@ -3,6 +3,7 @@ package github
 import (
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -182,3 +183,70 @@ func TestGenerateToolsetsHelp(t *testing.T) {
 	assert.Contains(t, helpText, "gists")
 	assert.Contains(t, helpText, "notifications")
 }
+
+func TestValidateCustomToolsets(t *testing.T) {
+	triage := func(id, icon string, tools ...string) inventory.CustomToolset {
+		return inventory.CustomToolset{
+			ToolsetMetadata: inventory.ToolsetMetadata{ID: inventory.ToolsetID(id), Icon: icon},
+			Tools:           tools,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		toolsets []inventory.CustomToolset
+		errMsg   string
+	}{
+		{
+			name:     "valid",
+			toolsets: []inventory.CustomToolset{triage("triage", "issue-opened", "issue_read", "label_write")},
+		},
+		{
+			name:     "missing id",
+			toolsets: []inventory.CustomToolset{triage("", "", "issue_read")},
+			errMsg:   "custom toolset 1 has no id",
+		},
+		{
+			name:     "glob in id",
+			toolsets: []inventory.CustomToolset{triage("tri*", "", "issue_read")},
+			errMsg:   "must not contain",
+		},
+		{
+			name:     "built-in id",
+			toolsets: []inventory.CustomToolset{triage("issues", "", "issue_read")},
+			errMsg:   "already used by a built-in toolset",
+		},
+		{
+			name:     "keyword id",
+			toolsets: []inventory.CustomToolset{triage("default", "", "issue_read")},
+			errMsg:   "already used by a built-in toolset",
+		},
+		{
+			name:     "duplicate id",
+			toolsets: []inventory.CustomToolset{triage("triage", "", "issue_read"), triage("triage", "", "label_write")},
+			errMsg:   "declared more than once",
+		},
+		{
+			name:     "no tools",
+			toolsets: []inventory.CustomToolset{triage("triage", "")},
+			errMsg:   "lists no tools",
+		},
+		{
+			name:     "unknown icon",
+			toolsets: []inventory.CustomToolset{triage("triage", "no-such-icon", "issue_read")},
+			errMsg:   "not an embedded octicon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustomToolsets(tt.toolsets)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
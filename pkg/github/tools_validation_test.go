@@ -1,9 +1,11 @@
 package github
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -184,3 +186,123 @@ func TestToolsetMetadataConsistency(t *testing.T) {
 		}
 	}
 }
+
+// TestAllToolsHaveCompleteAnnotations validates that all tools set the hints that clients and
+// the inventory filters rely on:
+// - OpenWorldHint must be set, as every tool reaches GitHub
+// - write tools must set DestructiveHint, so that --no-destructive can tell them apart
+// - read-only tools must not be marked destructive
+// - tools that delete, or have a method that deletes, updates, removes or cancels, must be
+// marked destructive
+func TestAllToolsHaveCompleteAnnotations(t *testing.T) {
+	tools := AllTools(stubTranslation)
+
+	for _, tool := range tools {
+		t.Run(tool.Tool.Name, func(t *testing.T) {
+			annotations := tool.Tool.Annotations
+			require.NotNil(t, annotations, "Tool %q must have Annotations", tool.Tool.Name)
+
+			require.NotNil(t, annotations.OpenWorldHint,
+				"Tool %q must set OpenWorldHint", tool.Tool.Name)
+			assert.True(t, *annotations.OpenWorldHint,
+				"Tool %q calls GitHub, so OpenWorldHint should be true", tool.Tool.Name)
+
+			if tool.IsReadOnly() {
+				assert.False(t, tool.IsDestructive(),
+					"Read-only tool %q must not be marked destructive", tool.Tool.Name)
+				return
+			}
+			assert.NotNil(t, annotations.DestructiveHint,
+				"Write tool %q must set DestructiveHint explicitly", tool.Tool.Name)
+			if strings.HasPrefix(tool.Tool.Name, "delete_") {
+				assert.True(t, tool.IsDestructive(),
+					"Tool %q deletes data, so it must be marked destructive", tool.Tool.Name)
+			}
+			for _, method := range toolMethodValues(tool) {
+				for _, verb := range []string{"delete", "update", "remove", "cancel"} {
+					if strings.Contains(method, verb) {
+						assert.True(t, tool.IsDestructive(),
+							"Tool %q has method %q, so it must be marked destructive", tool.Tool.Name, method)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestWriteToolAnnotations pins the destructive and idempotent hints of every write tool, so
+// that a new write tool, or a change to the hints of one, is a deliberate decision. A tool is
+// destructive if any of its methods may delete, overwrite or undo existing data, such as file
+// contents, issue and pull request fields, project field values, labels, subscriptions,
+// notification state, stars or running workflows, rather than only add to it. Merging a pull
+// request cannot be undone, so it is destructive too. It is
+// idempotent if repeating a call with the same arguments has no further effect.
+func TestWriteToolAnnotations(t *testing.T) {
+	expected := map[string]struct{ destructive, idempotent bool }{
+		"actions_run_trigger":                         {true, false},
+		"add_comment_to_pending_review":               {false, false},
+		"add_issue_comment":                           {false, false},
+		"add_project_item":                            {false, true},
+		"assign_copilot_to_issue":                     {false, true},
+		"cancel_workflow_run":                         {true, true},
+		"create_branch":                               {false, true},
+		"create_gist":                                 {false, false},
+		"create_or_update_file":                       {true, false},
+		"create_pull_request":                         {false, true},
+		"create_repository":                           {false, true},
+		"delete_file":                                 {true, true},
+		"delete_project_item":                         {true, true},
+		"delete_workflow_run_logs":                    {true, true},
+		"dismiss_notification":                        {true, true},
+		"fork_repository":                             {false, true},
+		"issue_write":                                 {true, false},
+		"label_write":                                 {true, false},
+		"manage_notification_subscription":            {true, true},
+		"manage_repository_notification_subscription": {true, true},
+		"mark_all_notifications_read":                 {true, true},
+		"merge_pull_request":                          {true, true},
+		"projects_write":                              {true, true},
+		"pull_request_review_write":                   {true, false},
+		"push_files":                                  {true, false},
+		"request_copilot_review":                      {false, true},
+		"rerun_failed_jobs":                           {false, false},
+		"rerun_workflow_run":                          {false, false},
+		"run_workflow":                                {false, false},
+		"star_repository":                             {false, true},
+		"sub_issue_write":                             {true, true},
+		"unstar_repository":                           {true, true},
+		"update_gist":                                 {true, true},
+		"update_project_item":                         {true, true},
+		"update_pull_request":                         {true, true},
+		"update_pull_request_branch":                  {false, false},
+	}
+
+	for _, tool := range AllTools(stubTranslation) {
+		if tool.IsReadOnly() {
+			continue
+		}
+		t.Run(tool.Tool.Name, func(t *testing.T) {
+			want, ok := expected[tool.Tool.Name]
+			require.True(t, ok, "Write tool %q is missing from the expected annotations", tool.Tool.Name)
+			assert.Equal(t, want.destructive, tool.IsDestructive(),
+				"Tool %q has an unexpected DestructiveHint", tool.Tool.Name)
+			assert.Equal(t, want.idempotent, tool.Tool.Annotations.IdempotentHint,
+				"Tool %q has an unexpected IdempotentHint", tool.Tool.Name)
+		})
+	}
+}
+
+// toolMethodValues returns the values of the method argument of a tool, if it lists them.
+func toolMethodValues(tool inventory.ServerTool) []string {
+	schema, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil || schema.Properties["method"] == nil {
+		return nil
+	}
+	var methods []string
+	for _, value := range schema.Properties["method"].Enum {
+		if method, ok := value.(string); ok {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
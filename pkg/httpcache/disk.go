@@ -0,0 +1,193 @@
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiskStore is a Store that keeps entries as files in a directory, so that the cache
+// survives restarts. Once the files exceed a total size, the least recently used are removed.
+//
+// Each file is named after the hash of its repository and its key, so that entries of a
+// repository can be removed without reading them.
+type DiskStore struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	files map[string]diskFile // key -> file
+}
+
+type diskFile struct {
+	name     string
+	size     int64
+	lastUsed time.Time
+}
+
+// diskFileExt is the extension of cache files, so that other files in the directory are left alone.
+const diskFileExt = ".json"
+
+// NewDiskStore creates a DiskStore in dir, creating the directory if needed and picking up
+// entries written by earlier runs.
+func NewDiskStore(dir string, maxBytes int64) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	s := &DiskStore{dir: dir, maxBytes: maxBytes, files: make(map[string]diskFile)}
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		_, key, ok := parseDiskFileName(name)
+		if !ok || dirEntry.IsDir() {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		s.files[key] = diskFile{name: name, size: info.Size(), lastUsed: info.ModTime()}
+		s.size += info.Size()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict()
+	return s, nil
+}
+
+// Get implements Store. Unreadable files are treated as missing.
+func (s *DiskStore) Get(key string) (*Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, ok := s.files[key]
+	if !ok {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, file.name))
+	if err != nil {
+		s.remove(key)
+		return nil, false
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		s.remove(key)
+		return nil, false
+	}
+
+	// The modification time records use, so that eviction order survives restarts
+	now := time.Now()
+	_ = os.Chtimes(filepath.Join(s.dir, file.name), now, now)
+	file.lastUsed = now
+	s.files[key] = file
+	return &entry, true
+}
+
+// Set implements Store. Failures to write are ignored, as the entry can be fetched again.
+func (s *DiskStore) Set(entry *Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(entry.Key)
+	if int64(len(data)) > s.maxBytes {
+		return
+	}
+
+	name := diskFileName(entry.Repo, entry.Key)
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	s.files[entry.Key] = diskFile{name: name, size: int64(len(data)), lastUsed: time.Now()}
+	s.size += int64(len(data))
+	s.evict()
+}
+
+// InvalidateRepo implements Store.
+func (s *DiskStore) InvalidateRepo(owner, repo string) {
+	prefix := repoHash(repoKey(owner, repo)) + "-"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, file := range s.files {
+		if strings.HasPrefix(file.name, prefix) {
+			s.remove(key)
+		}
+	}
+}
+
+// evict removes the least recently used files until the store fits its size. The caller must hold mu.
+func (s *DiskStore) evict() {
+	if s.size <= s.maxBytes {
+		return
+	}
+	keys := make([]string, 0, len(s.files))
+	for key := range s.files {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return s.files[keys[i]].lastUsed.Before(s.files[keys[j]].lastUsed) })
+	for _, key := range keys {
+		if s.size <= s.maxBytes {
+			return
+		}
+		s.remove(key)
+	}
+}
+
+// remove deletes the file of a key, if any. The caller must hold mu.
+func (s *DiskStore) remove(key string) {
+	file, ok := s.files[key]
+	if !ok {
+		return
+	}
+	_ = os.Remove(filepath.Join(s.dir, file.name))
+	delete(s.files, key)
+	s.size -= file.size
+}
+
+func diskFileName(repo, key string) string {
+	return repoHash(repo) + "-" + key + diskFileExt
+}
+
+func parseDiskFileName(name string) (repo, key string, ok bool) {
+	base, found := strings.CutSuffix(name, diskFileExt)
+	if !found {
+		return "", "", false
+	}
+	return strings.Cut(base, "-")
+}
+
+// repoHash names the repository in file names without revealing it.
+func repoHash(repo string) string {
+	sum := sha256.Sum256([]byte(repo))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,85 @@
+package httpcache
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStore_PersistsEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir, 1<<20)
+	require.NoError(t, err)
+
+	entry := &Entry{
+		Key:        "key",
+		Repo:       "owner/repo",
+		ETag:       `"v1"`,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       []byte(`{}`),
+	}
+	store.Set(entry)
+
+	reopened, err := NewDiskStore(dir, 1<<20)
+	require.NoError(t, err)
+	got, ok := reopened.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, entry, got)
+
+	_, ok = reopened.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestDiskStore_InvalidateRepo(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewDiskStore(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	store.Set(&Entry{Key: "a", Repo: "owner/repo"})
+	store.Set(&Entry{Key: "b", Repo: "owner/other"})
+	store.Set(&Entry{Key: "c"})
+
+	store.InvalidateRepo("Owner", "Repo")
+
+	_, ok := store.Get("a")
+	assert.False(t, ok)
+	_, ok = store.Get("b")
+	assert.True(t, ok)
+	_, ok = store.Get("c")
+	assert.True(t, ok)
+}
+
+func TestDiskStore_EvictsToSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir, 1<<20)
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		store.Set(&Entry{Key: key, Body: make([]byte, 1000)})
+	}
+
+	// Reopening with a smaller limit evicts down to it
+	reopened, err := NewDiskStore(dir, 3000)
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*"+diskFileExt))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// Corrupt files are dropped rather than served
+	for _, file := range files {
+		require.NoError(t, os.WriteFile(file, []byte("not json"), 0o600))
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		_, ok := reopened.Get(key)
+		assert.False(t, ok)
+	}
+}
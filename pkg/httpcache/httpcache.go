@@ -0,0 +1,198 @@
+// Package httpcache caches GitHub API responses and revalidates them with conditional
+// requests, so that unchanged resources are answered by a 304 that does not count
+// against the rate limit.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+)
+
+// Entry is a cached response along with the validators used to revalidate it.
+type Entry struct {
+	// Key identifies the request the response was returned for
+	Key string `json:"key"`
+	// Repo is the "owner/repo" the response belongs to, if any, for invalidation
+	Repo string `json:"repo,omitempty"`
+
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// size approximates the memory used by an entry.
+func (e *Entry) size() int64 {
+	n := len(e.Key) + len(e.Repo) + len(e.ETag) + len(e.LastModified) + len(e.Body)
+	for name, values := range e.Header {
+		n += len(name)
+		for _, v := range values {
+			n += len(v)
+		}
+	}
+	return int64(n)
+}
+
+// Store holds cached responses. Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the entry stored under key.
+	Get(key string) (*Entry, bool)
+	// Set stores an entry under its key, evicting older entries as needed.
+	Set(entry *Entry)
+	// InvalidateRepo removes every entry belonging to a repository.
+	InvalidateRepo(owner, repo string)
+}
+
+// repoKey normalizes a repository for comparison, as owner and repo names are case insensitive.
+func repoKey(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+// headerFromCache is set on responses served from the cache after a 304.
+const headerFromCache = "X-From-Cache"
+
+// Transport is an http.RoundTripper that caches GET responses carrying an ETag or
+// Last-Modified header, and revalidates them with If-None-Match or If-Modified-Since.
+// A 304 is answered with the cached response. It must run below the transport that
+// sets the Authorization header, as responses are cached per credential.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Store holds the cached responses.
+	Store Store
+
+	// RawURL is the base URL of the raw content API, so that raw file responses can be
+	// attributed to their repository for invalidation.
+	RawURL *url.URL
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !cacheable(req) {
+		return t.base().RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, hit := t.Store.Get(key)
+	if hit && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	} else {
+		hit = false
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if hit && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cachedResponse(cached, req, resp), nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.Store.Set(&Entry{
+		Key:          key,
+		Repo:         t.repoForURL(req.URL),
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	})
+	return resp, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// repoForURL returns the repository a request URL belongs to, or "" if it is not repository scoped.
+func (t *Transport) repoForURL(u *url.URL) string {
+	owner, repo, ok := utils.RepoFromURL(u, t.RawURL)
+	if !ok {
+		return ""
+	}
+	return repoKey(owner, repo)
+}
+
+// cacheable reports whether a request may be answered from the cache.
+func cacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return false
+	}
+	return !strings.Contains(req.Header.Get("Cache-Control"), "no-store")
+}
+
+// cacheKey identifies a request by its URL, the representation it asks for and its
+// credential, so that one token is never served a response fetched with another.
+// The credential is hashed so that it is not kept in memory or written to disk.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{
+		req.Header.Get("Authorization"),
+		req.Header.Get("Accept"),
+		req.Header.Get("X-GitHub-Api-Version"),
+		req.URL.String(),
+	} {
+		_, _ = io.WriteString(h, part)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedResponse builds the response for a request answered by a 304, using the headers
+// of the 304 (such as the current rate limit) over the cached headers.
+func cachedResponse(entry *Entry, req *http.Request, notModified *http.Response) *http.Response {
+	header := entry.Header.Clone()
+	for name, values := range notModified.Header {
+		if name == "Content-Length" || name == "Content-Type" {
+			continue
+		}
+		header[name] = values
+	}
+	header.Set(headerFromCache, "1")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,175 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// etagServer serves a fixed body with an ETag, answering matching conditional requests with a 304.
+func etagServer(t *testing.T, body string, notModified *atomic.Int32) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4998")
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func get(t *testing.T, client *http.Client, url, token string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransport_RevalidatesWithETag(t *testing.T) {
+	t.Parallel()
+
+	var notModified atomic.Int32
+	ts := etagServer(t, `{"title": "issue"}`, &notModified)
+	client := &http.Client{Transport: &Transport{Store: NewMemoryStore(1 << 20)}}
+
+	resp, body := get(t, client, ts.URL+"/repos/owner/repo/issues/1", "token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"title": "issue"}`, body)
+	assert.Empty(t, resp.Header.Get(headerFromCache))
+
+	resp, body = get(t, client, ts.URL+"/repos/owner/repo/issues/1", "token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"title": "issue"}`, body)
+	assert.Equal(t, "1", resp.Header.Get(headerFromCache))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	// Headers of the 304 take precedence, so the rate limit is current
+	assert.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestTransport_CachesPerCredential(t *testing.T) {
+	t.Parallel()
+
+	var notModified atomic.Int32
+	ts := etagServer(t, `{}`, &notModified)
+	client := &http.Client{Transport: &Transport{Store: NewMemoryStore(1 << 20)}}
+
+	get(t, client, ts.URL+"/user", "first")
+	resp, _ := get(t, client, ts.URL+"/user", "second")
+	assert.Empty(t, resp.Header.Get(headerFromCache))
+	assert.Equal(t, int32(0), notModified.Load())
+}
+
+func TestTransport_SkipsUncacheableRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	store := NewMemoryStore(1 << 20)
+	client := &http.Client{Transport: &Transport{Store: store}}
+
+	resp, err := client.Post(ts.URL+"/repos/owner/repo/issues", "application/json", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	get(t, client, ts.URL+"/repos/owner/repo/issues", "token")
+	assert.Equal(t, 0, store.Len(), "only 200 responses to GET requests are cached")
+}
+
+func TestTransport_InvalidateRepo(t *testing.T) {
+	t.Parallel()
+
+	var notModified atomic.Int32
+	ts := etagServer(t, `{}`, &notModified)
+	store := NewMemoryStore(1 << 20)
+	client := &http.Client{Transport: &Transport{Store: store}}
+
+	get(t, client, ts.URL+"/repos/Owner/Repo/issues/1", "token")
+	get(t, client, ts.URL+"/repos/owner/other/issues/1", "token")
+	require.Equal(t, 2, store.Len())
+
+	store.InvalidateRepo("owner", "repo")
+	assert.Equal(t, 1, store.Len())
+
+	resp, _ := get(t, client, ts.URL+"/repos/Owner/Repo/issues/1", "token")
+	assert.Empty(t, resp.Header.Get(headerFromCache))
+}
+
+func TestTransport_RepoForURL(t *testing.T) {
+	t.Parallel()
+
+	rawURL, err := url.Parse("https://ghes.example.com/raw/")
+	require.NoError(t, err)
+	transport := &Transport{RawURL: rawURL}
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://api.github.com/repos/Owner/Repo/pulls/1", expected: "owner/repo"},
+		{url: "https://ghes.example.com/api/v3/repos/owner/repo/contents/README.md", expected: "owner/repo"},
+		{url: "https://ghes.example.com/raw/owner/repo/HEAD/README.md", expected: "owner/repo"},
+		{url: "https://api.github.com/user", expected: ""},
+		{url: "https://api.github.com/search/issues?q=repo:owner/repo", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, transport.repoForURL(u))
+		})
+	}
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	entry := func(key string) *Entry { return &Entry{Key: key, Body: make([]byte, 100)} }
+	entrySize := entry("a").size()
+	store := NewMemoryStore(2 * entrySize)
+
+	store.Set(entry("a"))
+	store.Set(entry("b"))
+	_, _ = store.Get("a")
+	store.Set(entry("c"))
+
+	_, ok := store.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	_, ok = store.Get("a")
+	assert.True(t, ok)
+	_, ok = store.Get("c")
+	assert.True(t, ok)
+
+	store.Set(&Entry{Key: "big", Body: make([]byte, 1000)})
+	_, ok = store.Get("big")
+	assert.False(t, ok, "entries larger than the store are not kept")
+	assert.Equal(t, 2, store.Len())
+}
@@ -0,0 +1,85 @@
+package httpcache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// MemoryStore is a Store that keeps entries in memory, evicting the least recently used
+// entries once their total size exceeds a limit.
+type MemoryStore struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+// NewMemoryStore creates a MemoryStore holding at most maxBytes of entries.
+func NewMemoryStore(maxBytes int64) *MemoryStore {
+	return &MemoryStore{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(key string) (*Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return elem.Value.(*Entry), true
+}
+
+// Set implements Store. Entries larger than the store are not kept.
+func (s *MemoryStore) Set(entry *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[entry.Key]; ok {
+		s.remove(elem)
+	}
+	if entry.size() > s.maxBytes {
+		return
+	}
+
+	s.entries[entry.Key] = s.order.PushFront(entry)
+	s.size += entry.size()
+	for s.size > s.maxBytes {
+		s.remove(s.order.Back())
+	}
+}
+
+// InvalidateRepo implements Store.
+func (s *MemoryStore) InvalidateRepo(owner, repo string) {
+	key := repoKey(owner, repo)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for elem := s.order.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*Entry).Repo == key {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// Len returns the number of entries in the store.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *MemoryStore) remove(elem *list.Element) {
+	entry := s.order.Remove(elem).(*Entry)
+	delete(s.entries, entry.Key)
+	s.size -= entry.size()
+}
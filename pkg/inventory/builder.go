@@ -2,6 +2,9 @@ package inventory
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -10,6 +13,12 @@ import (
 // Returns true if the tool should be included, false to exclude it.
 type ToolFilter func(ctx context.Context, tool *ServerTool) (bool, error)
 
+// namedFilter is a builder filter with the name it is reported by in tool decisions.
+type namedFilter struct {
+	name   string
+	filter ToolFilter
+}
+
 // Builder builds a Registry with the specified configuration.
 // Use NewBuilder to create a builder, chain configuration methods,
 // then call Build() to create the final inventory.
@@ -34,11 +43,14 @@ type Builder struct {
 
 	// Configuration options (processed at Build time)
 	readOnly        bool
-	toolsetIDs      []string // raw input, processed at Build()
-	toolsetIDsIsNil bool     // tracks if nil was passed (nil = defaults)
-	additionalTools []string // raw input, processed at Build()
+	noDestructive   bool
+	toolsetIDs      []string        // raw input, processed at Build()
+	toolsetIDsIsNil bool            // tracks if nil was passed (nil = defaults)
+	additionalTools []string        // raw input, processed at Build()
+	excludeTools    []string        // raw input, processed at Build()
+	customToolsets  []CustomToolset // member names resolved at Build()
 	featureChecker  FeatureFlagChecker
-	filters         []ToolFilter // filters to apply to all tools
+	filters         []namedFilter // filters to apply to all tools
 }
 
 // NewBuilder creates a new Builder.
@@ -83,7 +95,15 @@ func (b *Builder) WithReadOnly(readOnly bool) *Builder {
 	return b
 }
 
+// WithNoDestructive sets whether tools marked destructive should be hidden. Other write tools,
+// such as those that add comments, stay available. Returns self for chaining.
+func (b *Builder) WithNoDestructive(noDestructive bool) *Builder {
+	b.noDestructive = noDestructive
+	return b
+}
+
 // WithToolsets specifies which toolsets should be enabled.
+// IDs may be glob patterns (e.g. "code_*"), which enable every toolset they match.
 // Special keywords:
 //   - "all": enables all toolsets
 //   - "default": expands to toolsets marked with Default: true in their metadata
@@ -101,13 +121,26 @@ func (b *Builder) WithToolsets(toolsetIDs []string) *Builder {
 // WithTools specifies additional tools that bypass toolset filtering.
 // These tools are additive - they will be included even if their toolset is not enabled.
 // Read-only filtering still applies to these tools.
+// Names may be glob patterns (e.g. "get_*"), matched against canonical tool names.
 // Deprecated tool aliases are automatically resolved to their canonical names during Build().
+// Names and patterns that match no tool are reported by UnrecognizedTools.
 // Returns self for chaining.
 func (b *Builder) WithTools(toolNames []string) *Builder {
 	b.additionalTools = toolNames
 	return b
 }
 
+// WithExcludeTools specifies tools to exclude, even if their toolset is enabled or they are
+// passed to WithTools. Names may be glob patterns (e.g. "*delete*"), and deprecated aliases
+// are resolved as in WithTools. The exclusion is applied as a builder filter named
+// "exclude_tools", after any filters added with WithFilter.
+// Names and patterns that match no tool are reported by UnrecognizedTools.
+// Returns self for chaining.
+func (b *Builder) WithExcludeTools(toolNames []string) *Builder {
+	b.excludeTools = toolNames
+	return b
+}
+
 // WithFeatureChecker sets the feature flag checker function.
 // The checker receives a context (for actor extraction) and feature flag name,
 // returns (enabled, error). If error occurs, it will be logged and treated as false.
@@ -121,9 +154,18 @@ func (b *Builder) WithFeatureChecker(checker FeatureFlagChecker) *Builder {
 // WithFilter adds a filter function that will be applied to all tools.
 // Multiple filters can be added and are evaluated in order.
 // If any filter returns false or an error, the tool is excluded.
+// The filter is reported as "filter N" (its 1-based position) in tool decisions;
+// use WithNamedFilter to give it a descriptive name.
 // Returns self for chaining.
 func (b *Builder) WithFilter(filter ToolFilter) *Builder {
-	b.filters = append(b.filters, filter)
+	return b.WithNamedFilter(fmt.Sprintf("filter %d", len(b.filters)+1), filter)
+}
+
+// WithNamedFilter adds a filter like WithFilter, reporting it by name in tool decisions
+// so that ExplainTools can say which filter excluded a tool.
+// Returns self for chaining.
+func (b *Builder) WithNamedFilter(name string, filter ToolFilter) *Builder {
+	b.filters = append(b.filters, namedFilter{name: name, filter: filter})
 	return b
 }
 
@@ -138,6 +180,7 @@ func (b *Builder) Build() *Inventory {
 		prompts:           b.prompts,
 		deprecatedAliases: b.deprecatedAliases,
 		readOnly:          b.readOnly,
+		noDestructive:     b.noDestructive,
 		featureChecker:    b.featureChecker,
 		filters:           b.filters,
 	}
@@ -145,22 +188,94 @@ func (b *Builder) Build() *Inventory {
 	// Process toolsets and pre-compute metadata in a single pass
 	r.enabledToolsets, r.unrecognizedToolsets, r.toolsetIDs, r.toolsetIDSet, r.defaultToolsetIDs, r.toolsetDescriptions = b.processToolsets()
 
-	// Process additional tools (resolve aliases)
+	// Process custom toolsets (resolve member aliases and patterns)
+	r.customToolsets, r.unrecognizedTools = b.resolveCustomToolsets()
+
+	// Process additional tools (resolve aliases and patterns)
 	if len(b.additionalTools) > 0 {
 		r.additionalTools = make(map[string]bool, len(b.additionalTools))
 		for _, name := range b.additionalTools {
-			// Resolve deprecated aliases to canonical names
-			if canonical, isAlias := b.deprecatedAliases[name]; isAlias {
-				r.additionalTools[canonical] = true
-			} else {
-				r.additionalTools[name] = true
+			matches := b.matchToolNames(name)
+			if len(matches) == 0 {
+				r.unrecognizedTools = append(r.unrecognizedTools, name)
+			}
+			for _, match := range matches {
+				r.additionalTools[match] = true
 			}
 		}
 	}
 
+	// Process excluded tools (resolve aliases and patterns) into a filter
+	if len(b.excludeTools) > 0 {
+		excluded := make(map[string]bool)
+		for _, name := range b.excludeTools {
+			matches := b.matchToolNames(name)
+			if len(matches) == 0 {
+				r.unrecognizedTools = append(r.unrecognizedTools, name)
+			}
+			for _, match := range matches {
+				excluded[match] = true
+			}
+		}
+		r.filters = append(slices.Clone(r.filters), namedFilter{
+			name: excludeToolsFilterName,
+			filter: func(_ context.Context, tool *ServerTool) (bool, error) {
+				return !excluded[tool.Tool.Name], nil
+			},
+		})
+	}
+
 	return r
 }
 
+// excludeToolsFilterName names the filter built from WithExcludeTools in tool decisions.
+const excludeToolsFilterName = "exclude_tools"
+
+// isGlobPattern reports whether name is a glob pattern rather than a plain name.
+func isGlobPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// matchGlob reports whether name matches a glob pattern. Invalid patterns match nothing.
+func matchGlob(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// matchToolNames returns the canonical names of the tools a name or glob pattern refers to.
+// Plain names that are deprecated aliases resolve to their canonical names, while patterns
+// only match canonical names. Tools with several variants are returned once.
+func (b *Builder) matchToolNames(pattern string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	if !isGlobPattern(pattern) {
+		if canonical, isAlias := b.deprecatedAliases[pattern]; isAlias {
+			pattern = canonical
+		}
+		for i := range b.tools {
+			if b.tools[i].Tool.Name == pattern {
+				add(pattern)
+			}
+		}
+		return result
+	}
+
+	for i := range b.tools {
+		if matchGlob(pattern, b.tools[i].Tool.Name) {
+			add(b.tools[i].Tool.Name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 // processToolsets processes the toolsetIDs configuration and returns:
 // - enabledToolsets map (nil means all enabled)
 // - unrecognizedToolsets list for warnings
@@ -204,6 +319,16 @@ func (b *Builder) processToolsets() (map[ToolsetID]bool, []string, []ToolsetID,
 			descriptions[p.Toolset.ID] = p.Toolset.Description
 		}
 	}
+	for i := range b.customToolsets {
+		ts := &b.customToolsets[i]
+		validIDs[ts.ID] = true
+		if ts.Default {
+			defaultIDs[ts.ID] = true
+		}
+		if ts.Description != "" {
+			descriptions[ts.ID] = ts.Description
+		}
+	}
 
 	// Build sorted slices from the collected maps
 	allToolsetIDs := make([]ToolsetID, 0, len(validIDs))
@@ -249,6 +374,21 @@ func (b *Builder) processToolsets() (map[ToolsetID]bool, []string, []ToolsetID,
 					expanded = append(expanded, defaultID)
 				}
 			}
+		} else if isGlobPattern(trimmed) {
+			// Expand patterns to the toolsets they match
+			matched := false
+			for _, tsID := range allToolsetIDs {
+				if matchGlob(trimmed, string(tsID)) {
+					matched = true
+					if !seen[tsID] {
+						seen[tsID] = true
+						expanded = append(expanded, tsID)
+					}
+				}
+			}
+			if !matched {
+				unrecognized = append(unrecognized, trimmed)
+			}
 		} else {
 			tsID := ToolsetID(trimmed)
 			if !seen[tsID] {
@@ -0,0 +1,82 @@
+package inventory
+
+import (
+	"slices"
+	"sort"
+)
+
+// CustomToolset is a toolset defined by configuration rather than by the tools in it. It
+// groups tools from any toolsets under a new ID, which can be enabled like any other
+// toolset. Enabling it enables its member tools, in addition to their own toolsets.
+type CustomToolset struct {
+	// ToolsetMetadata holds the ID, description and icon of the toolset. Default marks
+	// the toolset as part of the "default" keyword.
+	ToolsetMetadata
+	// Tools are the names of the member tools. Names may be glob patterns (e.g. "get_*")
+	// and deprecated aliases, as in Builder.WithTools.
+	Tools []string
+}
+
+// WithCustomToolsets adds toolsets defined by configuration. Their IDs are accepted by
+// WithToolsets, including in glob patterns and the "default" keyword, and by the dynamic
+// toolset methods. Member names that match no tool are reported by UnrecognizedTools.
+// Returns self for chaining.
+func (b *Builder) WithCustomToolsets(toolsets []CustomToolset) *Builder {
+	b.customToolsets = toolsets
+	return b
+}
+
+// resolveCustomToolsets returns the custom toolsets with their member names resolved to
+// sorted canonical tool names, and the names that match no tool.
+func (b *Builder) resolveCustomToolsets() ([]CustomToolset, []string) {
+	if len(b.customToolsets) == 0 {
+		return nil, nil
+	}
+	var unrecognized []string
+	result := make([]CustomToolset, 0, len(b.customToolsets))
+	for _, ts := range b.customToolsets {
+		resolved := CustomToolset{ToolsetMetadata: ts.ToolsetMetadata}
+		for _, name := range ts.Tools {
+			matches := b.matchToolNames(name)
+			if len(matches) == 0 {
+				unrecognized = append(unrecognized, name)
+			}
+			for _, match := range matches {
+				if !slices.Contains(resolved.Tools, match) {
+					resolved.Tools = append(resolved.Tools, match)
+				}
+			}
+		}
+		sort.Strings(resolved.Tools)
+		result = append(result, resolved)
+	}
+	return result, unrecognized
+}
+
+// CustomToolsets returns the toolsets added with WithCustomToolsets, in the order they were
+// added, with their member names resolved to canonical tool names.
+func (r *Inventory) CustomToolsets() []CustomToolset {
+	return r.customToolsets
+}
+
+// isCustomToolsetMember reports whether a tool is a member of the custom toolset with the given
+// ID. It returns false if there is no such custom toolset.
+func (r *Inventory) isCustomToolsetMember(toolsetID ToolsetID, toolName string) bool {
+	for i := range r.customToolsets {
+		if r.customToolsets[i].ID == toolsetID {
+			return slices.Contains(r.customToolsets[i].Tools, toolName)
+		}
+	}
+	return false
+}
+
+// enabledCustomToolset returns the ID of an enabled custom toolset the tool is a member of.
+func (r *Inventory) enabledCustomToolset(toolName string) (ToolsetID, bool) {
+	for i := range r.customToolsets {
+		ts := &r.customToolsets[i]
+		if r.isToolsetEnabled(ts.ID) && slices.Contains(ts.Tools, toolName) {
+			return ts.ID, true
+		}
+	}
+	return "", false
+}
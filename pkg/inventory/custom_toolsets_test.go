@@ -0,0 +1,119 @@
+package inventory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCustomToolsets(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("issue_read", "issues", true),
+		mockTool("issue_write", "issues", false),
+		mockTool("label_write", "labels", false),
+		mockTool("list_notifications", "notifications", true),
+		mockTool("get_file_contents", "repos", true),
+	}
+	triage := CustomToolset{
+		ToolsetMetadata: ToolsetMetadata{ID: "triage", Description: "Triage issues"},
+		Tools:           []string{"issue_read", "label_*", "old_notifications", "missing_tool"},
+	}
+
+	tests := []struct {
+		name     string
+		toolsets []string
+		readOnly bool
+		expected []string
+	}{
+		{
+			name:     "custom toolset enables its members",
+			toolsets: []string{"triage"},
+			expected: []string{"issue_read", "label_write", "list_notifications"},
+		},
+		{
+			name:     "custom toolset matched by pattern",
+			toolsets: []string{"tri*"},
+			expected: []string{"issue_read", "label_write", "list_notifications"},
+		},
+		{
+			name:     "combined with a built-in toolset",
+			toolsets: []string{"triage", "repos"},
+			expected: []string{"issue_read", "label_write", "list_notifications", "get_file_contents"},
+		},
+		{
+			name:     "read-only still applies",
+			toolsets: []string{"triage"},
+			readOnly: true,
+			expected: []string{"issue_read", "list_notifications"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reg := NewBuilder().
+				SetTools(tools).
+				WithDeprecatedAliases(map[string]string{"old_notifications": "list_notifications"}).
+				WithCustomToolsets([]CustomToolset{triage}).
+				WithToolsets(tc.toolsets).
+				WithReadOnly(tc.readOnly).
+				Build()
+
+			var names []string
+			for _, tool := range reg.AvailableTools(context.Background()) {
+				names = append(names, tool.Tool.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("Expected tools %v, got %v", tc.expected, names)
+			}
+			if unrecognized := reg.UnrecognizedToolsets(); len(unrecognized) > 0 {
+				t.Errorf("Expected no unrecognized toolsets, got %v", unrecognized)
+			}
+			if got := reg.UnrecognizedTools(); !reflect.DeepEqual(got, []string{"missing_tool"}) {
+				t.Errorf("Expected the unknown member to be unrecognized, got %v", got)
+			}
+		})
+	}
+}
+
+func TestCustomToolsetMetadata(t *testing.T) {
+	reg := NewBuilder().
+		SetTools([]ServerTool{
+			mockTool("issue_read", "issues", true),
+			mockTool("get_me", "context", true),
+		}).
+		WithCustomToolsets([]CustomToolset{{
+			ToolsetMetadata: ToolsetMetadata{ID: "triage", Description: "Triage issues", Default: true},
+			Tools:           []string{"issue_read"},
+		}}).
+		WithToolsets([]string{"default"}).
+		Build()
+
+	if !reg.HasToolset("triage") {
+		t.Error("Expected the custom toolset to be known")
+	}
+	if got := reg.ToolsetIDs(); !reflect.DeepEqual(got, []ToolsetID{"context", "issues", "triage"}) {
+		t.Errorf("Expected custom toolset in toolset IDs, got %v", got)
+	}
+	if got := reg.ToolsetDescriptions()["triage"]; got != "Triage issues" {
+		t.Errorf("Expected custom toolset description, got %q", got)
+	}
+	if got := reg.EnabledToolsetIDs(); !reflect.DeepEqual(got, []ToolsetID{"triage"}) {
+		t.Errorf("Expected default custom toolset to be enabled, got %v", got)
+	}
+	if got := reg.ToolsForToolset("triage"); len(got) != 1 || got[0].Tool.Name != "issue_read" {
+		t.Errorf("Expected the member tools for the custom toolset, got %v", got)
+	}
+
+	decisions, err := reg.ExplainTool(context.Background(), "issue_read")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decisions[0].Reason != "custom toolset triage is enabled" {
+		t.Errorf("Expected the custom toolset to be reported, got %q", decisions[0].Reason)
+	}
+
+	reg.DisableToolset("triage")
+	if got := reg.AvailableTools(context.Background()); len(got) != 0 {
+		t.Errorf("Expected no tools after disabling the custom toolset, got %d", len(got))
+	}
+}
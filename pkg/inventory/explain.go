@@ -0,0 +1,138 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FilterStage identifies a stage of tool filtering, in the order the stages are evaluated.
+type FilterStage string
+
+const (
+	// FilterStageEnabledFunc is the tool's own Enabled function.
+	FilterStageEnabledFunc FilterStage = "enabled_func"
+	// FilterStageFeatureFlag is the FeatureFlagEnable/FeatureFlagDisable check.
+	FilterStageFeatureFlag FilterStage = "feature_flag"
+	// FilterStageReadOnly is the read-only filter.
+	FilterStageReadOnly FilterStage = "read_only"
+	// FilterStageNoDestructive is the filter hiding destructive tools.
+	FilterStageNoDestructive FilterStage = "no_destructive"
+	// FilterStageBuilderFilter is a filter added with WithFilter or WithNamedFilter.
+	FilterStageBuilderFilter FilterStage = "filter"
+	// FilterStageAdditionalTool is membership of the tools added with WithTools.
+	FilterStageAdditionalTool FilterStage = "additional_tool"
+	// FilterStageToolset is membership of an enabled toolset or custom toolset.
+	FilterStageToolset FilterStage = "toolset"
+)
+
+// FilterStep records the outcome of one filter stage for a tool.
+type FilterStep struct {
+	Stage FilterStage `json:"stage"`
+	// Filter names the builder filter, for FilterStageBuilderFilter steps.
+	Filter  string `json:"filter,omitempty"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+	// Error is set when the stage failed to evaluate.
+	Error string `json:"error,omitempty"`
+}
+
+// ToolDecision explains whether a tool is enabled. Steps lists every stage evaluated for
+// the tool in order; the last step decided the outcome, and its stage and reason are
+// repeated in Stage and Reason.
+type ToolDecision struct {
+	Tool    string       `json:"tool"`
+	Toolset ToolsetID    `json:"toolset"`
+	Enabled bool         `json:"enabled"`
+	Stage   FilterStage  `json:"stage"`
+	Reason  string       `json:"reason"`
+	Steps   []FilterStep `json:"steps"`
+}
+
+// toolTrace collects the filter steps evaluated for a tool. Its methods are safe to call
+// on a nil trace, which records nothing. Each method returns allowed, so that a stage can
+// record its outcome and return it in one statement.
+type toolTrace struct {
+	steps []FilterStep
+}
+
+func (t *toolTrace) record(stage FilterStage, allowed bool, format string, args ...any) bool {
+	if t != nil {
+		t.steps = append(t.steps, FilterStep{Stage: stage, Allowed: allowed, Reason: fmt.Sprintf(format, args...)})
+	}
+	return allowed
+}
+
+func (t *toolTrace) recordError(stage FilterStage, allowed bool, err error, format string, args ...any) bool {
+	if t != nil {
+		t.steps = append(t.steps, FilterStep{Stage: stage, Allowed: allowed, Reason: fmt.Sprintf(format, args...), Error: err.Error()})
+	}
+	return allowed
+}
+
+func (t *toolTrace) recordFilter(name string, allowed bool, err error) bool {
+	if t != nil {
+		step := FilterStep{Stage: FilterStageBuilderFilter, Filter: name, Allowed: allowed}
+		switch {
+		case err != nil:
+			step.Reason = fmt.Sprintf("filter %s failed", name)
+			step.Error = err.Error()
+		case allowed:
+			step.Reason = fmt.Sprintf("allowed by filter %s", name)
+		default:
+			step.Reason = fmt.Sprintf("excluded by filter %s", name)
+		}
+		t.steps = append(t.steps, step)
+	}
+	return allowed
+}
+
+// explainTool evaluates the filters for a tool, recording each stage.
+func (r *Inventory) explainTool(ctx context.Context, tool *ServerTool) ToolDecision {
+	trace := &toolTrace{}
+	enabled := r.evaluateTool(ctx, tool, trace)
+	last := trace.steps[len(trace.steps)-1]
+	return ToolDecision{
+		Tool:    tool.Tool.Name,
+		Toolset: tool.Toolset.ID,
+		Enabled: enabled,
+		Stage:   last.Stage,
+		Reason:  last.Reason,
+		Steps:   trace.steps,
+	}
+}
+
+// ExplainTools returns a decision for every tool in the inventory, enabled or not,
+// explaining which filter stage decided whether it is available. Errors from filters are
+// reported in the decisions rather than logged. Decisions are sorted by toolset ID, then
+// tool name; tools with several feature-flagged variants have a decision per variant.
+// The context is used for feature flag evaluation.
+func (r *Inventory) ExplainTools(ctx context.Context) []ToolDecision {
+	result := make([]ToolDecision, 0, len(r.tools))
+	for i := range r.tools {
+		result = append(result, r.explainTool(ctx, &r.tools[i]))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Toolset != result[j].Toolset {
+			return result[i].Toolset < result[j].Toolset
+		}
+		return result[i].Tool < result[j].Tool
+	})
+
+	return result
+}
+
+// ExplainTool returns the decisions for the tool with the given name, resolving deprecated
+// aliases. It returns a decision per feature-flagged variant of the tool, and an error if
+// no tool has the name.
+func (r *Inventory) ExplainTool(ctx context.Context, name string) ([]ToolDecision, error) {
+	var result []ToolDecision
+	for _, tool := range r.filterToolsByName(name) {
+		result = append(result, r.explainTool(ctx, &tool))
+	}
+	if len(result) == 0 {
+		return nil, NewToolDoesNotExistError(name)
+	}
+	return result, nil
+}
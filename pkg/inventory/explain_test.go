@@ -0,0 +1,155 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExplainTools(t *testing.T) {
+	disabledTool := mockTool("disabled_tool", "toolset1", true)
+	disabledTool.Enabled = func(_ context.Context) (bool, error) { return false, nil }
+	failingTool := mockTool("failing_tool", "toolset1", true)
+	failingTool.Enabled = func(_ context.Context) (bool, error) { return false, errors.New("boom") }
+
+	tools := []ServerTool{
+		disabledTool,
+		failingTool,
+		mockToolWithFlags("flagged_tool", "toolset1", true, "new_flag", ""),
+		mockToolWithFlags("replaced_tool", "toolset1", true, "", "old_flag"),
+		mockTool("write_tool", "toolset1", false),
+		mockTool("filtered_tool", "toolset1", true),
+		mockTool("extra_tool", "toolset2", true),
+		mockTool("hidden_tool", "toolset2", true),
+		mockTool("read_tool", "toolset1", true),
+	}
+
+	checker := func(_ context.Context, flag string) (bool, error) {
+		return flag == "old_flag", nil
+	}
+	reg := NewBuilder().
+		SetTools(tools).
+		WithToolsets([]string{"toolset1"}).
+		WithTools([]string{"extra_tool"}).
+		WithReadOnly(true).
+		WithFeatureChecker(checker).
+		WithNamedFilter("no_filtered", func(_ context.Context, tool *ServerTool) (bool, error) {
+			return tool.Tool.Name != "filtered_tool", nil
+		}).
+		Build()
+
+	decisions := make(map[string]ToolDecision)
+	for _, decision := range reg.ExplainTools(context.Background()) {
+		decisions[decision.Tool] = decision
+	}
+	if len(decisions) != len(tools) {
+		t.Fatalf("Expected %d decisions, got %d", len(tools), len(decisions))
+	}
+
+	tests := []struct {
+		tool    string
+		enabled bool
+		stage   FilterStage
+		reason  string
+	}{
+		{"disabled_tool", false, FilterStageEnabledFunc, "the tool disabled itself"},
+		{"failing_tool", false, FilterStageEnabledFunc, "the tool's availability check failed"},
+		{"flagged_tool", false, FilterStageFeatureFlag, "requires feature flag new_flag"},
+		{"replaced_tool", false, FilterStageFeatureFlag, "replaced while feature flag old_flag is enabled"},
+		{"write_tool", false, FilterStageReadOnly, "write tool hidden in read-only mode"},
+		{"filtered_tool", false, FilterStageBuilderFilter, "excluded by filter no_filtered"},
+		{"extra_tool", true, FilterStageAdditionalTool, "enabled individually as an additional tool"},
+		{"hidden_tool", false, FilterStageToolset, "toolset toolset2 is not enabled"},
+		{"read_tool", true, FilterStageToolset, "toolset toolset1 is enabled"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tool, func(t *testing.T) {
+			decision := decisions[tc.tool]
+			if decision.Enabled != tc.enabled {
+				t.Errorf("Expected enabled=%t, got %t", tc.enabled, decision.Enabled)
+			}
+			if decision.Stage != tc.stage {
+				t.Errorf("Expected stage %q, got %q", tc.stage, decision.Stage)
+			}
+			if decision.Reason != tc.reason {
+				t.Errorf("Expected reason %q, got %q", tc.reason, decision.Reason)
+			}
+		})
+	}
+
+	if got := decisions["failing_tool"].Steps[0].Error; got != "boom" {
+		t.Errorf("Expected the Enabled error in the trace, got %q", got)
+	}
+
+	expectedSteps := []FilterStep{
+		{Stage: FilterStageReadOnly, Allowed: true, Reason: "read-only tool"},
+		{Stage: FilterStageBuilderFilter, Filter: "no_filtered", Allowed: true, Reason: "allowed by filter no_filtered"},
+		{Stage: FilterStageToolset, Allowed: true, Reason: "toolset toolset1 is enabled"},
+	}
+	if got := decisions["read_tool"].Steps; !reflect.DeepEqual(got, expectedSteps) {
+		t.Errorf("Expected steps %+v, got %+v", expectedSteps, got)
+	}
+}
+
+func TestExplainTools_MatchesAvailableTools(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset1", false),
+		mockTool("tool3", "toolset2", true),
+	}
+
+	reg := NewBuilder().
+		SetTools(tools).
+		WithToolsets([]string{"toolset1"}).
+		WithFilter(func(_ context.Context, tool *ServerTool) (bool, error) {
+			return tool.Tool.Name != "tool2", nil
+		}).
+		Build()
+
+	var enabled []string
+	for _, decision := range reg.ExplainTools(context.Background()) {
+		if decision.Enabled {
+			enabled = append(enabled, decision.Tool)
+		}
+		if decision.Tool == "tool2" && decision.Reason != "excluded by filter filter 1" {
+			t.Errorf("Expected unnamed filter to be reported by position, got %q", decision.Reason)
+		}
+	}
+	var available []string
+	for _, tool := range reg.AvailableTools(context.Background()) {
+		available = append(available, tool.Tool.Name)
+	}
+	if !reflect.DeepEqual(enabled, available) {
+		t.Errorf("Expected enabled decisions %v to match available tools %v", enabled, available)
+	}
+}
+
+func TestExplainTool(t *testing.T) {
+	tools := []ServerTool{
+		mockToolWithFlags("get_job_logs", "actions", true, "", "consolidated_flag"),
+		mockToolWithFlags("get_job_logs", "actions", true, "consolidated_flag", ""),
+	}
+
+	reg := NewBuilder().
+		SetTools(tools).
+		WithDeprecatedAliases(map[string]string{"old_job_logs": "get_job_logs"}).
+		WithToolsets([]string{"all"}).
+		Build()
+
+	decisions, err := reg.ExplainTool(context.Background(), "old_job_logs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(decisions) != 2 {
+		t.Fatalf("Expected a decision per variant, got %d", len(decisions))
+	}
+	if !decisions[0].Enabled || decisions[1].Enabled {
+		t.Errorf("Expected only the variant without the enable flag to be enabled, got %+v", decisions)
+	}
+
+	if _, err := reg.ExplainTool(context.Background(), "nonexistent"); err == nil {
+		t.Error("Expected an error for an unknown tool")
+	}
+}
@@ -24,10 +24,7 @@ func (r *Inventory) isToolsetEnabled(toolsetID ToolsetID) bool {
 // checkFeatureFlag checks a feature flag using the feature checker.
 // Returns false if checker is nil or returns an error (errors are logged).
 func (r *Inventory) checkFeatureFlag(ctx context.Context, flagName string) bool {
-	if r.featureChecker == nil || flagName == "" {
-		return false
-	}
-	enabled, err := r.featureChecker(ctx, flagName)
+	enabled, err := r.evalFeatureFlag(ctx, flagName)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Feature flag check error for %q: %v\n", flagName, err)
 		return false
@@ -35,6 +32,19 @@ func (r *Inventory) checkFeatureFlag(ctx context.Context, flagName string) bool
 	return enabled
 }
 
+// evalFeatureFlag checks a feature flag using the feature checker, returning any error
+// instead of logging it. Returns false if checker is nil.
+func (r *Inventory) evalFeatureFlag(ctx context.Context, flagName string) (bool, error) {
+	if r.featureChecker == nil || flagName == "" {
+		return false, nil
+	}
+	enabled, err := r.featureChecker(ctx, flagName)
+	if err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
 // isFeatureFlagAllowed checks if an item passes feature flag filtering.
 // - If FeatureFlagEnable is set, the item is only allowed if the flag is enabled
 // - If FeatureFlagDisable is set, the item is excluded if the flag is enabled
@@ -51,52 +61,106 @@ func (r *Inventory) isFeatureFlagAllowed(ctx context.Context, enableFlag, disabl
 }
 
 // isToolEnabled checks if a specific tool is enabled based on current filters.
+// Errors from the tool's Enabled function, the feature checker and builder filters
+// exclude the tool and are logged to stderr.
+func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
+	return r.evaluateTool(ctx, tool, nil)
+}
+
+// evaluateTool checks if a specific tool is enabled based on current filters, recording
+// each stage it evaluates in trace. A nil trace records nothing, and errors are then
+// logged to stderr instead.
 // Filter evaluation order:
 //  1. Tool.Enabled (tool self-filtering)
 //  2. FeatureFlagEnable/FeatureFlagDisable
 //  3. Read-only filter
-//  4. Builder filters (via WithFilter)
-//  5. Toolset/additional tools
-func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
+//  4. Destructive filter
+//  5. Builder filters (via WithFilter)
+//  6. Toolset/additional tools/custom toolsets
+func (r *Inventory) evaluateTool(ctx context.Context, tool *ServerTool, trace *toolTrace) bool {
 	// 1. Check tool's own Enabled function first
 	if tool.Enabled != nil {
 		enabled, err := tool.Enabled(ctx)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Tool.Enabled check error for %q: %v\n", tool.Tool.Name, err)
-			return false
+			if trace == nil {
+				fmt.Fprintf(os.Stderr, "Tool.Enabled check error for %q: %v\n", tool.Tool.Name, err)
+			}
+			return trace.recordError(FilterStageEnabledFunc, false, err, "the tool's availability check failed")
 		}
 		if !enabled {
-			return false
+			return trace.record(FilterStageEnabledFunc, false, "the tool disabled itself")
 		}
+		trace.record(FilterStageEnabledFunc, true, "the tool enabled itself")
 	}
 	// 2. Check feature flags
-	if !r.isFeatureFlagAllowed(ctx, tool.FeatureFlagEnable, tool.FeatureFlagDisable) {
-		return false
+	if flag := tool.FeatureFlagEnable; flag != "" {
+		enabled, err := r.evalFeatureFlag(ctx, flag)
+		if err != nil {
+			if trace == nil {
+				fmt.Fprintf(os.Stderr, "Feature flag check error for %q: %v\n", flag, err)
+			}
+			return trace.recordError(FilterStageFeatureFlag, false, err, "failed to check feature flag %s", flag)
+		}
+		if !enabled {
+			return trace.record(FilterStageFeatureFlag, false, "requires feature flag %s", flag)
+		}
+		trace.record(FilterStageFeatureFlag, true, "feature flag %s is enabled", flag)
+	}
+	if flag := tool.FeatureFlagDisable; flag != "" {
+		enabled, err := r.evalFeatureFlag(ctx, flag)
+		if err != nil && trace == nil {
+			// A failed check treats the flag as disabled, so the tool stays available
+			fmt.Fprintf(os.Stderr, "Feature flag check error for %q: %v\n", flag, err)
+		}
+		if enabled {
+			return trace.record(FilterStageFeatureFlag, false, "replaced while feature flag %s is enabled", flag)
+		}
+		if err != nil {
+			trace.recordError(FilterStageFeatureFlag, true, err, "failed to check feature flag %s, treating it as disabled", flag)
+		} else {
+			trace.record(FilterStageFeatureFlag, true, "feature flag %s is not enabled", flag)
+		}
 	}
 	// 3. Check read-only filter (applies to all tools)
-	if r.readOnly && !tool.IsReadOnly() {
-		return false
+	if r.readOnly {
+		if !tool.IsReadOnly() {
+			return trace.record(FilterStageReadOnly, false, "write tool hidden in read-only mode")
+		}
+		trace.record(FilterStageReadOnly, true, "read-only tool")
 	}
-	// 4. Apply builder filters
-	for _, filter := range r.filters {
-		allowed, err := filter(ctx, tool)
+	// 4. Check destructive filter (applies to all tools)
+	if r.noDestructive {
+		if tool.IsDestructive() {
+			return trace.record(FilterStageNoDestructive, false, "destructive tool hidden")
+		}
+		trace.record(FilterStageNoDestructive, true, "not a destructive tool")
+	}
+	// 5. Apply builder filters
+	for _, f := range r.filters {
+		allowed, err := f.filter(ctx, tool)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Builder filter error for tool %q: %v\n", tool.Tool.Name, err)
-			return false
+			if trace == nil {
+				fmt.Fprintf(os.Stderr, "Builder filter error for tool %q: %v\n", tool.Tool.Name, err)
+			}
+			return trace.recordFilter(f.name, false, err)
 		}
 		if !allowed {
-			return false
+			return trace.recordFilter(f.name, false, nil)
 		}
+		trace.recordFilter(f.name, true, nil)
 	}
-	// 5. Check if tool is in additionalTools (bypasses toolset filter)
+	// 6. Check if tool is in additionalTools (bypasses toolset filter)
 	if r.additionalTools != nil && r.additionalTools[tool.Tool.Name] {
-		return true
+		return trace.record(FilterStageAdditionalTool, true, "enabled individually as an additional tool")
 	}
-	// 5. Check toolset filter
-	if !r.isToolsetEnabled(tool.Toolset.ID) {
-		return false
+	// 6. Check toolset filter, then the custom toolsets listing the tool
+	if r.isToolsetEnabled(tool.Toolset.ID) {
+		return trace.record(FilterStageToolset, true, "toolset %s is enabled", tool.Toolset.ID)
 	}
-	return true
+	if customID, ok := r.enabledCustomToolset(tool.Tool.Name); ok {
+		return trace.record(FilterStageToolset, true, "custom toolset %s is enabled", customID)
+	}
+	return trace.record(FilterStageToolset, false, "toolset %s is not enabled", tool.Toolset.ID)
 }
 
 // AvailableTools returns the tools that pass all current filters,
@@ -225,18 +289,22 @@ func (r *Inventory) filterPromptsByName(name string) []ServerPrompt {
 	return []ServerPrompt{}
 }
 
-// ToolsForToolset returns all tools belonging to a specific toolset.
+// ToolsForToolset returns all tools belonging to a specific toolset, or listed by a custom
+// toolset with that ID.
 // This method bypasses the toolset enabled filter (for dynamic toolset registration),
-// but still respects the read-only filter.
+// but still respects the read-only and destructive filters.
 func (r *Inventory) ToolsForToolset(toolsetID ToolsetID) []ServerTool {
 	var result []ServerTool
 	for i := range r.tools {
 		tool := &r.tools[i]
-		// Only check read-only filter, not toolset enabled filter
-		if tool.Toolset.ID == toolsetID {
+		// Only check read-only and destructive filters, not toolset enabled filter
+		if tool.Toolset.ID == toolsetID || r.isCustomToolsetMember(toolsetID, tool.Tool.Name) {
 			if r.readOnly && !tool.IsReadOnly() {
 				continue
 			}
+			if r.noDestructive && tool.IsDestructive() {
+				continue
+			}
 			result = append(result, *tool)
 		}
 	}
@@ -249,6 +317,44 @@ func (r *Inventory) ToolsForToolset(toolsetID ToolsetID) []ServerTool {
 	return result
 }
 
+// ResourceTemplatesForToolset returns the resource templates belonging to a specific toolset
+// that pass feature flag filtering, bypassing the toolset enabled filter (for dynamic
+// toolset registration). The context is used for feature flag evaluation.
+func (r *Inventory) ResourceTemplatesForToolset(ctx context.Context, toolsetID ToolsetID) []ServerResourceTemplate {
+	var result []ServerResourceTemplate
+	for i := range r.resourceTemplates {
+		res := &r.resourceTemplates[i]
+		if res.Toolset.ID == toolsetID && r.isFeatureFlagAllowed(ctx, res.FeatureFlagEnable, res.FeatureFlagDisable) {
+			result = append(result, *res)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Template.Name < result[j].Template.Name
+	})
+
+	return result
+}
+
+// PromptsForToolset returns the prompts belonging to a specific toolset that pass feature
+// flag filtering, bypassing the toolset enabled filter (for dynamic toolset registration).
+// The context is used for feature flag evaluation.
+func (r *Inventory) PromptsForToolset(ctx context.Context, toolsetID ToolsetID) []ServerPrompt {
+	var result []ServerPrompt
+	for i := range r.prompts {
+		prompt := &r.prompts[i]
+		if prompt.Toolset.ID == toolsetID && r.isFeatureFlagAllowed(ctx, prompt.FeatureFlagEnable, prompt.FeatureFlagDisable) {
+			result = append(result, *prompt)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Prompt.Name < result[j].Prompt.Name
+	})
+
+	return result
+}
+
 // IsToolsetEnabled checks if a toolset is currently enabled based on filters.
 func (r *Inventory) IsToolsetEnabled(toolsetID ToolsetID) bool {
 	return r.isToolsetEnabled(toolsetID)
@@ -264,6 +370,35 @@ func (r *Inventory) EnableToolset(toolsetID ToolsetID) {
 	r.enabledToolsets[toolsetID] = true
 }
 
+// EnableTools marks individual tools as enabled, bypassing toolset filtering like the tools
+// passed to WithTools. Deprecated tool aliases are resolved to their canonical names.
+// This is used by dynamic tool discovery to enable single tools without their toolsets.
+func (r *Inventory) EnableTools(toolNames ...string) {
+	if r.additionalTools == nil {
+		r.additionalTools = make(map[string]bool, len(toolNames))
+	}
+	for _, name := range toolNames {
+		if canonical, isAlias := r.deprecatedAliases[name]; isAlias {
+			name = canonical
+		}
+		r.additionalTools[name] = true
+	}
+}
+
+// DisableToolset marks a toolset as disabled in this group.
+// This is used by dynamic toolset management to shed toolsets that are no longer needed.
+// If all toolsets are enabled, every other known toolset stays enabled.
+func (r *Inventory) DisableToolset(toolsetID ToolsetID) {
+	if r.enabledToolsets == nil {
+		// nil means all enabled, so enable the others explicitly
+		r.enabledToolsets = make(map[ToolsetID]bool, len(r.toolsetIDs))
+		for _, id := range r.toolsetIDs {
+			r.enabledToolsets[id] = true
+		}
+	}
+	delete(r.enabledToolsets, toolsetID)
+}
+
 // EnabledToolsetIDs returns the list of enabled toolset IDs based on current filters.
 // Returns all toolset IDs if no filter is set.
 func (r *Inventory) EnabledToolsetIDs() []ToolsetID {
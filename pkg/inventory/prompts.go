@@ -16,6 +16,17 @@ type ServerPrompt struct {
 	FeatureFlagDisable string
 }
 
+// RegisterFunc registers the prompt with the server.
+// Icons are automatically applied from the toolset metadata if not already set.
+// A shallow copy of the prompt is made to avoid mutating the original.
+func (sp *ServerPrompt) RegisterFunc(s *mcp.Server) {
+	promptCopy := sp.Prompt
+	if len(promptCopy.Icons) == 0 {
+		promptCopy.Icons = sp.Toolset.Icons()
+	}
+	s.AddPrompt(&promptCopy, sp.Handler)
+}
+
 // NewServerPrompt creates a new ServerPrompt with toolset metadata.
 func NewServerPrompt(toolset ToolsetMetadata, prompt mcp.Prompt, handler mcp.PromptHandler) ServerPrompt {
 	return ServerPrompt{
@@ -43,21 +43,28 @@ type Inventory struct {
 	// Filters - these control what's returned by Available* methods
 	// readOnly when true filters out write tools
 	readOnly bool
+	// noDestructive when true filters out destructive tools
+	noDestructive bool
 	// enabledToolsets when non-nil, only include tools/resources/prompts from these toolsets
 	// when nil, all toolsets are enabled
 	enabledToolsets map[ToolsetID]bool
 	// additionalTools are specific tools that bypass toolset filtering (but still respect read-only)
 	// These are additive - a tool is included if it matches toolset filters OR is in this set
 	additionalTools map[string]bool
+	// customToolsets are toolsets defined by configuration, with member names resolved to
+	// canonical tool names. A tool is also included if an enabled custom toolset lists it
+	customToolsets []CustomToolset
 	// featureChecker when non-nil, checks if a feature flag is enabled.
 	// Takes context and flag name, returns (enabled, error). If error, log and treat as false.
 	// If checker is nil, all flag checks return false.
 	featureChecker FeatureFlagChecker
 	// filters are functions that will be applied to all tools during filtering.
 	// If any filter returns false or an error, the tool is excluded.
-	filters []ToolFilter
+	filters []namedFilter
 	// unrecognizedToolsets holds toolset IDs that were requested but don't match any registered toolsets
 	unrecognizedToolsets []string
+	// unrecognizedTools holds tool names and patterns that were included or excluded but don't match any tool
+	unrecognizedTools []string
 }
 
 // UnrecognizedToolsets returns toolset IDs that were passed to WithToolsets but don't
@@ -66,6 +73,12 @@ func (r *Inventory) UnrecognizedToolsets() []string {
 	return r.unrecognizedToolsets
 }
 
+// UnrecognizedTools returns tool names and patterns that were passed to WithTools or
+// WithExcludeTools but don't match any tool. This is useful for warning users about typos.
+func (r *Inventory) UnrecognizedTools() []string {
+	return r.unrecognizedTools
+}
+
 // MCP method constants for use with ForMCPRequest.
 const (
 	MCPMethodInitialize             = "initialize"
@@ -107,11 +120,14 @@ func (r *Inventory) ForMCPRequest(method string, itemName string) *Inventory {
 		prompts:              r.prompts,
 		deprecatedAliases:    r.deprecatedAliases,
 		readOnly:             r.readOnly,
+		noDestructive:        r.noDestructive,
 		enabledToolsets:      r.enabledToolsets, // shared, not modified
 		additionalTools:      r.additionalTools, // shared, not modified
+		customToolsets:       r.customToolsets,  // shared, not modified
 		featureChecker:       r.featureChecker,
 		filters:              r.filters, // shared, not modified
 		unrecognizedToolsets: r.unrecognizedToolsets,
+		unrecognizedTools:    r.unrecognizedTools,
 	}
 
 	// Helper to clear all item types
@@ -181,13 +197,7 @@ func (r *Inventory) RegisterTools(ctx context.Context, s *mcp.Server, deps any)
 // Icons are automatically applied from the toolset metadata if not already set.
 func (r *Inventory) RegisterResourceTemplates(ctx context.Context, s *mcp.Server, deps any) {
 	for _, res := range r.AvailableResourceTemplates(ctx) {
-		// Make a shallow copy to avoid mutating the original
-		templateCopy := res.Template
-		// Apply icons from toolset metadata if not already set
-		if len(templateCopy.Icons) == 0 {
-			templateCopy.Icons = res.Toolset.Icons()
-		}
-		s.AddResourceTemplate(&templateCopy, res.Handler(deps))
+		res.RegisterFunc(s, deps)
 	}
 }
 
@@ -196,13 +206,7 @@ func (r *Inventory) RegisterResourceTemplates(ctx context.Context, s *mcp.Server
 // Icons are automatically applied from the toolset metadata if not already set.
 func (r *Inventory) RegisterPrompts(ctx context.Context, s *mcp.Server) {
 	for _, prompt := range r.AvailablePrompts(ctx) {
-		// Make a shallow copy to avoid mutating the original
-		promptCopy := prompt.Prompt
-		// Apply icons from toolset metadata if not already set
-		if len(promptCopy.Icons) == 0 {
-			promptCopy.Icons = prompt.Toolset.Icons()
-		}
-		s.AddPrompt(&promptCopy, prompt.Handler)
+		prompt.RegisterFunc(s)
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -138,6 +140,58 @@ func TestWithReadOnly(t *testing.T) {
 	}
 }
 
+// mockDestructiveTool creates a write tool marked destructive for testing
+func mockDestructiveTool(name string, toolsetID string) ServerTool {
+	tool := mockTool(name, toolsetID, false)
+	destructive := true
+	tool.Tool.Annotations.DestructiveHint = &destructive
+	return tool
+}
+
+func TestWithNoDestructive(t *testing.T) {
+	safeWrite := mockTool("safe_write", "toolset1", false)
+	notDestructive := false
+	safeWrite.Tool.Annotations.DestructiveHint = &notDestructive
+	tools := []ServerTool{
+		mockTool("read_tool", "toolset1", true),
+		mockTool("write_tool", "toolset1", false),
+		safeWrite,
+		mockDestructiveTool("delete_tool", "toolset1"),
+	}
+
+	// Build without the destructive filter - should have all tools
+	reg := NewBuilder().SetTools(tools).WithToolsets([]string{"all"}).Build()
+	if got := len(reg.AvailableTools(context.Background())); got != 4 {
+		t.Fatalf("Expected 4 tools without the destructive filter, got %d", got)
+	}
+
+	// Build with the destructive filter - should filter out destructive tools, including
+	// write tools without a DestructiveHint
+	filtered := NewBuilder().SetTools(tools).WithToolsets([]string{"all"}).WithNoDestructive(true).Build()
+	available := filtered.AvailableTools(context.Background())
+	if len(available) != 2 {
+		t.Fatalf("Expected 2 tools with the destructive filter, got %d", len(available))
+	}
+	for _, tool := range available {
+		if tool.Tool.Name == "delete_tool" || tool.Tool.Name == "write_tool" {
+			t.Errorf("Expected %s to be hidden", tool.Tool.Name)
+		}
+	}
+
+	// ToolsForToolset bypasses the toolset filter but not the destructive filter
+	if got := len(filtered.ToolsForToolset("toolset1")); got != 2 {
+		t.Errorf("Expected 2 tools from ToolsForToolset, got %d", got)
+	}
+
+	decision, err := filtered.ExplainTool(context.Background(), "delete_tool")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decision[0].Enabled || decision[0].Stage != FilterStageNoDestructive {
+		t.Errorf("Expected delete_tool to be hidden at stage %q, got %+v", FilterStageNoDestructive, decision[0])
+	}
+}
+
 func TestWithToolsets(t *testing.T) {
 	tools := []ServerTool{
 		mockTool("tool1", "toolset1", true),
@@ -517,6 +571,127 @@ func TestWithToolsResolvesAliases(t *testing.T) {
 	}
 }
 
+func TestToolPatternsAndExclusions(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("get_issue_comments", "issues", true),
+		mockTool("issue_read", "issues", true),
+		mockTool("issue_write", "issues", false),
+		mockTool("delete_file", "repos", false),
+		mockTool("get_file_contents", "repos", true),
+		mockTool("list_code_scanning_alerts", "code_security", true),
+		mockTool("list_secret_scanning_alerts", "secret_protection", true),
+	}
+	aliases := map[string]string{"get_issue": "issue_read"}
+
+	tests := []struct {
+		name                 string
+		toolsets             []string
+		tools                []string
+		exclude              []string
+		expected             []string
+		expectedUnrecognized []string
+	}{
+		{
+			name:     "toolset except one tool",
+			toolsets: []string{"issues"},
+			exclude:  []string{"issue_write"},
+			expected: []string{"get_issue_comments", "issue_read"},
+		},
+		{
+			name:     "tool pattern",
+			toolsets: []string{},
+			tools:    []string{"get_*"},
+			expected: []string{"get_file_contents", "get_issue_comments"},
+		},
+		{
+			name:     "exclude pattern overrides included tools",
+			toolsets: []string{"repos"},
+			tools:    []string{"issue_write"},
+			exclude:  []string{"*delete*", "*write"},
+			expected: []string{"get_file_contents"},
+		},
+		{
+			name:     "exclude resolves aliases",
+			toolsets: []string{"issues"},
+			exclude:  []string{"get_issue"},
+			expected: []string{"get_issue_comments", "issue_write"},
+		},
+		{
+			name:     "pattern does not match aliases",
+			toolsets: []string{},
+			tools:    []string{"get_issue*"},
+			expected: []string{"get_issue_comments"},
+		},
+		{
+			name:     "toolset pattern",
+			toolsets: []string{"code_*", "secret_*"},
+			expected: []string{"list_code_scanning_alerts", "list_secret_scanning_alerts"},
+		},
+		{
+			name:                 "unknown names and patterns",
+			toolsets:             []string{"issues"},
+			tools:                []string{"get_isue", "list_*"},
+			exclude:              []string{"*remove*", "[bad"},
+			expected:             []string{"get_issue_comments", "issue_read", "issue_write", "list_code_scanning_alerts", "list_secret_scanning_alerts"},
+			expectedUnrecognized: []string{"get_isue", "*remove*", "[bad"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := NewBuilder().
+				SetTools(tools).
+				WithDeprecatedAliases(aliases).
+				WithToolsets(tt.toolsets).
+				WithTools(tt.tools).
+				WithExcludeTools(tt.exclude).
+				Build()
+
+			var names []string
+			for _, tool := range reg.AvailableTools(context.Background()) {
+				names = append(names, tool.Tool.Name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected tools %v, got %v", tt.expected, names)
+			}
+			if unrecognized := reg.UnrecognizedTools(); !reflect.DeepEqual(unrecognized, tt.expectedUnrecognized) {
+				t.Errorf("Expected unrecognized %v, got %v", tt.expectedUnrecognized, unrecognized)
+			}
+		})
+	}
+}
+
+func TestExcludeToolsExplained(t *testing.T) {
+	reg := NewBuilder().
+		SetTools([]ServerTool{mockTool("issue_write", "issues", false)}).
+		WithToolsets([]string{"all"}).
+		WithExcludeTools([]string{"*_write"}).
+		Build()
+
+	decisions, err := reg.ExplainTool(context.Background(), "issue_write")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decisions[0].Enabled || decisions[0].Reason != "excluded by filter exclude_tools" {
+		t.Errorf("Expected issue_write to be excluded by the exclude_tools filter, got %+v", decisions[0])
+	}
+}
+
+func TestUnrecognizedToolsetPatterns(t *testing.T) {
+	reg := NewBuilder().
+		SetTools([]ServerTool{mockTool("tool1", "toolset1", true)}).
+		WithToolsets([]string{"tool*", "nothing*"}).
+		Build()
+
+	if unrecognized := reg.UnrecognizedToolsets(); !reflect.DeepEqual(unrecognized, []string{"nothing*"}) {
+		t.Errorf("Expected unmatched pattern to be unrecognized, got %v", unrecognized)
+	}
+	if !reg.IsToolsetEnabled("toolset1") {
+		t.Error("Expected toolset1 to be enabled by the pattern")
+	}
+}
+
 func TestHasToolset(t *testing.T) {
 	tools := []ServerTool{
 		mockTool("tool1", "toolset1", true),
@@ -556,6 +731,56 @@ func TestEnabledToolsetIDs(t *testing.T) {
 	}
 }
 
+func TestDisableToolset(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset2", true),
+	}
+
+	for _, toolsets := range [][]string{{"toolset1", "toolset2"}, {"all"}} {
+		reg := NewBuilder().SetTools(tools).WithToolsets(toolsets).Build()
+		reg.DisableToolset("toolset1")
+
+		if reg.IsToolsetEnabled("toolset1") {
+			t.Errorf("%v: expected toolset1 to be disabled", toolsets)
+		}
+		if !reg.IsToolsetEnabled("toolset2") {
+			t.Errorf("%v: expected toolset2 to stay enabled", toolsets)
+		}
+		available := reg.AvailableTools(context.Background())
+		if len(available) != 1 || available[0].Tool.Name != "tool2" {
+			t.Errorf("%v: expected only tool2 to be available, got %d tools", toolsets, len(available))
+		}
+	}
+}
+
+func TestEnableTools(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset2", true),
+		mockTool("tool3", "toolset2", false),
+	}
+
+	reg := NewBuilder().
+		SetTools(tools).
+		WithDeprecatedAliases(map[string]string{"old_tool2": "tool2"}).
+		WithToolsets([]string{"toolset1"}).
+		WithReadOnly(true).
+		Build()
+	reg.EnableTools("old_tool2", "tool3")
+
+	available := reg.AvailableTools(context.Background())
+	if len(available) != 2 {
+		t.Fatalf("Expected 2 available tools, got %d", len(available))
+	}
+	if available[1].Tool.Name != "tool2" {
+		t.Errorf("Expected tool2 to be enabled through its alias, got %s", available[1].Tool.Name)
+	}
+	if reg.IsToolsetEnabled("toolset2") {
+		t.Error("Expected toolset2 to stay disabled")
+	}
+}
+
 func TestAllTools(t *testing.T) {
 	tools := []ServerTool{
 		mockTool("read_tool", "toolset1", true),
@@ -589,6 +814,33 @@ func TestServerToolIsReadOnly(t *testing.T) {
 	}
 }
 
+func TestServerToolIsDestructive(t *testing.T) {
+	unannotated := mockTool("write_tool", "toolset1", false)
+	unannotated.Tool.Annotations = nil
+	withoutHint := mockTool("write_tool", "toolset1", false)
+	notDestructive := mockTool("add_tool", "toolset1", false)
+	hint := false
+	notDestructive.Tool.Annotations.DestructiveHint = &hint
+	destructiveTool := mockDestructiveTool("delete_tool", "toolset1")
+	readTool := mockTool("read_tool", "toolset1", true)
+
+	if !unannotated.IsDestructive() {
+		t.Error("Expected tool without annotations to be destructive")
+	}
+	if !withoutHint.IsDestructive() {
+		t.Error("Expected write tool without DestructiveHint to be destructive")
+	}
+	if notDestructive.IsDestructive() {
+		t.Error("Expected tool with a false DestructiveHint to not be destructive")
+	}
+	if !destructiveTool.IsDestructive() {
+		t.Error("Expected tool with DestructiveHint to be destructive")
+	}
+	if readTool.IsDestructive() {
+		t.Error("Expected read-only tool to not be destructive")
+	}
+}
+
 // mockResource creates a minimal ServerResourceTemplate for testing
 func mockResource(name string, toolsetID string, uriTemplate string) ServerResourceTemplate {
 	return NewServerResourceTemplate(
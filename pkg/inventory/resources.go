@@ -38,6 +38,19 @@ func (sr *ServerResourceTemplate) Handler(deps any) mcp.ResourceHandler {
 	return sr.HandlerFunc(deps)
 }
 
+// RegisterFunc registers the resource template with the server using the provided dependencies.
+// Icons are automatically applied from the toolset metadata if not already set.
+// A shallow copy of the template is made to avoid mutating the original.
+// Panics if the resource has no handler - all resources should have handlers.
+func (sr *ServerResourceTemplate) RegisterFunc(s *mcp.Server, deps any) {
+	handler := sr.Handler(deps) // This will panic if HandlerFunc is nil
+	templateCopy := sr.Template
+	if len(templateCopy.Icons) == 0 {
+		templateCopy.Icons = sr.Toolset.Icons()
+	}
+	s.AddResourceTemplate(&templateCopy, handler)
+}
+
 // NewServerResourceTemplate creates a new ServerResourceTemplate with toolset metadata.
 func NewServerResourceTemplate(toolset ToolsetMetadata, resourceTemplate mcp.ResourceTemplate, handlerFn ResourceHandlerFunc) ServerResourceTemplate {
 	return ServerResourceTemplate{
@@ -0,0 +1,222 @@
+package inventory
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Weights of the fields of a tool in search scores. A query term found in the tool's name
+// counts for more than one found in its description or the description of a parameter.
+const (
+	searchWeightName        = 4.0
+	searchWeightTitle       = 3.0
+	searchWeightDescription = 1.0
+	searchWeightAnnotation  = 1.0
+	searchWeightParameter   = 0.5
+
+	// searchSaturation limits how much repeating a term in a tool's fields adds to its score.
+	searchSaturation = 1.2
+)
+
+// searchStopWords are words too common in queries and descriptions to rank tools by.
+var searchStopWords = map[string]bool{
+	"a": true, "all": true, "an": true, "and": true, "any": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "do": true, "for": true, "from": true, "how": true,
+	"i": true, "in": true, "into": true, "is": true, "it": true, "its": true, "me": true,
+	"my": true, "of": true, "on": true, "or": true, "some": true, "that": true, "the": true, "this": true,
+	"to": true, "use": true, "want": true, "what": true, "which": true, "with": true, "you": true,
+}
+
+// searchCanonicalTerms maps terms to the spelling they are indexed under, so that common
+// abbreviations match the words tools are described with.
+var searchCanonicalTerms = map[string]string{
+	"repository": "repo",
+	"pr":         "pull_request",
+	"ci":         "workflow",
+	"org":        "organization",
+}
+
+// ToolMatch is a tool found by ToolIndex.Search, with its relevance score.
+type ToolMatch struct {
+	Tool  ServerTool
+	Score float64
+}
+
+// ToolIndex is a keyword index over tools, for finding tools that match a natural-language
+// query. It indexes each tool's name, title, description, annotations and parameters.
+type ToolIndex struct {
+	tools []ServerTool
+	// terms holds the weighted frequency of each term in each tool, by tool index
+	terms []map[string]float64
+	// docFreq counts the tools each term appears in
+	docFreq map[string]int
+}
+
+// NewToolIndex builds a search index over tools.
+func NewToolIndex(tools []ServerTool) *ToolIndex {
+	idx := &ToolIndex{
+		tools:   tools,
+		terms:   make([]map[string]float64, len(tools)),
+		docFreq: make(map[string]int),
+	}
+	for i := range tools {
+		terms := toolTerms(&tools[i])
+		idx.terms[i] = terms
+		for term := range terms {
+			idx.docFreq[term]++
+		}
+	}
+	return idx
+}
+
+// Search returns up to limit tools matching query, best match first. Tools sharing a name
+// (feature-flagged variants) are returned once. A limit of zero or less returns all matches.
+func (idx *ToolIndex) Search(query string, limit int) []ToolMatch {
+	queryTerms := searchTerms(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	best := make(map[string]ToolMatch)
+	for i := range idx.tools {
+		score := idx.score(i, queryTerms)
+		if score == 0 {
+			continue
+		}
+		name := idx.tools[i].Tool.Name
+		if match, ok := best[name]; !ok || score > match.Score {
+			best[name] = ToolMatch{Tool: idx.tools[i], Score: score}
+		}
+	}
+
+	matches := make([]ToolMatch, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Tool.Tool.Name < matches[j].Tool.Tool.Name
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// score ranks a tool against the query terms, weighting rare terms above common ones.
+func (idx *ToolIndex) score(i int, queryTerms []string) float64 {
+	var score float64
+	total := float64(len(idx.tools))
+	for _, term := range queryTerms {
+		tf := idx.terms[i][term]
+		if tf == 0 {
+			continue
+		}
+		idf := math.Log(1 + total/float64(idx.docFreq[term]))
+		score += idf * tf * (searchSaturation + 1) / (tf + searchSaturation)
+	}
+	return score
+}
+
+// toolTerms returns the weighted frequency of each term in a tool's searchable fields.
+func toolTerms(tool *ServerTool) map[string]float64 {
+	terms := make(map[string]float64)
+	add := func(text string, weight float64) {
+		for _, term := range searchTerms(text) {
+			terms[term] += weight
+		}
+	}
+
+	add(tool.Tool.Name, searchWeightName)
+	add(tool.Tool.Title, searchWeightTitle)
+	add(tool.Tool.Description, searchWeightDescription)
+	if annotations := tool.Tool.Annotations; annotations != nil {
+		add(annotations.Title, searchWeightTitle)
+		if annotations.ReadOnlyHint {
+			add("read", searchWeightAnnotation)
+		} else {
+			add("write", searchWeightAnnotation)
+		}
+		if tool.IsDestructive() {
+			add("delete", searchWeightAnnotation)
+		}
+	}
+	add(string(tool.Toolset.ID), searchWeightAnnotation)
+	if schema := inputSchema(tool); schema != nil {
+		for name, property := range schema.Properties {
+			add(name, searchWeightParameter)
+			add(property.Description, searchWeightParameter)
+		}
+	}
+	return terms
+}
+
+// inputSchema returns a tool's input schema as a jsonschema.Schema, whatever form it was
+// declared in, or nil if it cannot be read.
+func inputSchema(tool *ServerTool) *jsonschema.Schema {
+	switch schema := tool.Tool.InputSchema.(type) {
+	case nil:
+		return nil
+	case *jsonschema.Schema:
+		return schema
+	default:
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return nil
+		}
+		var result jsonschema.Schema
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil
+		}
+		return &result
+	}
+}
+
+// searchTerms splits text into normalized search terms, dropping stop words.
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) < 2 || searchStopWords[word] {
+			continue
+		}
+		term := stem(word)
+		if canonical, ok := searchCanonicalTerms[term]; ok {
+			// A canonical spelling may stand for several words, such as pull_request
+			terms = append(terms, strings.Split(canonical, "_")...)
+			continue
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// stem strips common English plural and verb endings and a trailing "e", so that
+// "issues", "updated" and "updating" match "issue" and "update".
+func stem(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-3] + "y"
+	case len(word) > 4 && (strings.HasSuffix(word, "ches") || strings.HasSuffix(word, "shes") || strings.HasSuffix(word, "sses") || strings.HasSuffix(word, "xes")):
+		word = word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		word = word[:len(word)-1]
+	case len(word) > 5 && strings.HasSuffix(word, "ing"):
+		word = word[:len(word)-3]
+	case len(word) > 4 && strings.HasSuffix(word, "ed") && !strings.HasSuffix(word, "eed"):
+		word = word[:len(word)-2]
+	}
+	if len(word) > 4 && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+func searchTool(name, toolsetID, description string, params map[string]string) ServerTool {
+	tool := mockTool(name, toolsetID, true)
+	tool.Tool.Description = description
+	schema := &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}}
+	for param, paramDescription := range params {
+		schema.Properties[param] = &jsonschema.Schema{Type: "string", Description: paramDescription}
+	}
+	tool.Tool.InputSchema = schema
+	return tool
+}
+
+func TestToolIndexSearch(t *testing.T) {
+	tools := []ServerTool{
+		searchTool("list_workflow_runs", "actions", "List workflow runs for a repository", map[string]string{"status": "Filter runs by status, such as failure"}),
+		searchTool("merge_pull_request", "pull_requests", "Merge a pull request in a GitHub repository", nil),
+		searchTool("list_issues", "issues", "List issues in a GitHub repository", nil),
+		searchTool("add_issue_comment", "issues", "Add a comment to an issue or pull request", nil),
+		searchTool("get_me", "context", "Get details of the authenticated user", nil),
+	}
+	index := NewToolIndex(tools)
+
+	tests := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "name match ranks first",
+			query:    "list issues",
+			limit:    2,
+			expected: []string{"list_issues", "add_issue_comment"},
+		},
+		{
+			name:     "abbreviations and plurals",
+			query:    "merge PRs",
+			limit:    1,
+			expected: []string{"merge_pull_request"},
+		},
+		{
+			name:     "parameter descriptions",
+			query:    "failure",
+			expected: []string{"list_workflow_runs"},
+		},
+		{
+			name:     "stop words only",
+			query:    "how do I",
+			expected: nil,
+		},
+		{
+			name:     "no match",
+			query:    "kubernetes",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for _, match := range index.Search(tc.query, tc.limit) {
+				names = append(names, match.Tool.Tool.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("Search(%q) = %v, expected %v", tc.query, names, tc.expected)
+			}
+		})
+	}
+}
+
+func TestToolIndexSearch_VariantsListedOnce(t *testing.T) {
+	tools := []ServerTool{
+		mockToolWithFlags("get_job_logs", "actions", true, "", "consolidated_flag"),
+		mockToolWithFlags("get_job_logs", "actions", true, "consolidated_flag", ""),
+	}
+	tools[0].Tool.InputSchema = json.RawMessage(`{"type":"object","properties":{"job_id":{"type":"number","description":"The job logs to fetch"}}}`)
+
+	matches := NewToolIndex(tools).Search("job logs", 0)
+	if len(matches) != 1 {
+		t.Fatalf("Expected variants to be listed once, got %d matches", len(matches))
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"list_pull_requests", []string{"list", "pull", "request"}},
+		{"Updated repositories", []string{"updat", "repo"}},
+		{"updating the repository", []string{"updat", "repo"}},
+		{"branches and issues", []string{"branch", "issu"}},
+		{"a PR for CI", []string{"pull", "request", "workflow"}},
+	}
+
+	for _, tc := range tests {
+		if got := searchTerms(tc.text); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("searchTerms(%q) = %v, expected %v", tc.text, got, tc.expected)
+		}
+	}
+}
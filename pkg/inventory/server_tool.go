@@ -86,6 +86,17 @@ func (st *ServerTool) IsReadOnly() bool {
 	return st.Tool.Annotations != nil && st.Tool.Annotations.ReadOnlyHint
 }
 
+// IsDestructive returns true if this tool may delete or overwrite data rather than only add
+// to it. As in the MCP specification, a tool that is not read-only is destructive unless its
+// annotations set DestructiveHint to false.
+func (st *ServerTool) IsDestructive() bool {
+	if st.IsReadOnly() {
+		return false
+	}
+	annotations := st.Tool.Annotations
+	return annotations == nil || annotations.DestructiveHint == nil || *annotations.DestructiveHint
+}
+
 // HasHandler returns true if this tool has a handler function.
 func (st *ServerTool) HasHandler() bool {
 	return st.HandlerFunc != nil
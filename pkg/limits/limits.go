@@ -0,0 +1,215 @@
+// Package limits bounds how long tool calls may run and how many may run at once, so that a
+// slow GitHub request cannot hang the agent waiting on it.
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Reasons a tool call is rejected, reported in the structured content of its result.
+const (
+	ReasonTimeout     = "timeout"
+	ReasonConcurrency = "concurrency_limit"
+)
+
+// Config configures the limits on tool calls. The zero value imposes no limits.
+type Config struct {
+	// Timeout bounds tool calls without a timeout in ToolTimeouts. Zero means no timeout.
+	Timeout time.Duration
+
+	// ToolTimeouts overrides Timeout for individual tools, keyed by tool name. A zero timeout
+	// exempts the tool.
+	ToolTimeouts map[string]time.Duration
+
+	// MaxConcurrent caps the tool calls in flight across all sessions. Zero means no cap.
+	MaxConcurrent int
+}
+
+// Enabled reports whether any limit is configured.
+func (c Config) Enabled() bool {
+	return c.Timeout > 0 || len(c.ToolTimeouts) > 0 || c.MaxConcurrent > 0
+}
+
+// timeout returns the timeout for a call of tool, zero meaning none.
+func (c Config) timeout(tool string) time.Duration {
+	if timeout, ok := c.ToolTimeouts[tool]; ok {
+		return timeout
+	}
+	return c.Timeout
+}
+
+// ParseToolTimeouts parses per-tool timeouts given as tool=duration, e.g. "search_code=2m".
+func ParseToolTimeouts(values []string) (map[string]time.Duration, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	timeouts := make(map[string]time.Duration, len(values))
+	for _, value := range values {
+		tool, duration, ok := strings.Cut(value, "=")
+		tool = strings.TrimSpace(tool)
+		if !ok || tool == "" {
+			return nil, fmt.Errorf("invalid tool timeout %q: expected tool=duration", value)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool timeout %q: %w", value, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid tool timeout %q: must not be negative", value)
+		}
+		timeouts[tool] = timeout
+	}
+	return timeouts, nil
+}
+
+// Validate checks that every tool in ToolTimeouts is one of tools, so that a misspelled tool
+// name is not silently ignored.
+func (c Config) Validate(tools []inventory.ServerTool) error {
+	for tool := range c.ToolTimeouts {
+		if !slices.ContainsFunc(tools, func(t inventory.ServerTool) bool { return t.Tool.Name == tool }) {
+			return fmt.Errorf("invalid tool timeout for %q: no such tool", tool)
+		}
+	}
+	return nil
+}
+
+// Error is the structured content of the result of a tool call rejected by a limit.
+type Error struct {
+	Reason        string `json:"reason"`
+	Tool          string `json:"tool"`
+	Message       string `json:"message"`
+	Timeout       string `json:"timeout,omitempty"`
+	MaxConcurrent int    `json:"max_concurrent,omitempty"`
+}
+
+// Limiter enforces a Config. One Limiter should be shared by all servers of a process, so
+// that the concurrency cap applies across sessions.
+type Limiter struct {
+	cfg   Config
+	slots chan struct{}
+}
+
+// New creates a Limiter enforcing cfg.
+func New(cfg Config) *Limiter {
+	l := &Limiter{cfg: cfg}
+	if cfg.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return l
+}
+
+// Middleware returns receiving middleware that applies the limits to tool calls. A call
+// waits for a free slot until its timeout expires, or not at all if it has no timeout. A
+// call that cannot get a slot, or that runs past its timeout, gets an error result instead
+// of blocking the client; its handler keeps its slot until it returns.
+func (l *Limiter) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callRequest.Params == nil {
+				return next(ctx, method, req)
+			}
+			tool := callRequest.Params.Name
+
+			parent := ctx
+			timeout := l.cfg.timeout(tool)
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			if !l.acquire(ctx, timeout > 0) {
+				if err := parent.Err(); err != nil {
+					return nil, err
+				}
+				return l.concurrencyResult(tool), nil
+			}
+
+			type outcome struct {
+				result mcp.Result
+				err    error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				defer l.release()
+				result, err := next(ctx, method, req)
+				done <- outcome{result, err}
+			}()
+
+			select {
+			case out := <-done:
+				if parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return timeoutResult(tool, timeout), nil
+				}
+				return out.result, out.err
+			case <-ctx.Done():
+				if err := parent.Err(); err != nil {
+					return nil, err
+				}
+				return timeoutResult(tool, timeout), nil
+			}
+		}
+	}
+}
+
+// acquire takes a slot, waiting for one until ctx is done if wait is set.
+func (l *Limiter) acquire(ctx context.Context, wait bool) bool {
+	if l.slots == nil {
+		return true
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+	}
+	if !wait {
+		return false
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (l *Limiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+func (l *Limiter) concurrencyResult(tool string) *mcp.CallToolResult {
+	return errorResult(Error{
+		Reason:        ReasonConcurrency,
+		Tool:          tool,
+		Message:       fmt.Sprintf("%s was not run: the server is already running %d tool calls, try again later", tool, l.cfg.MaxConcurrent),
+		MaxConcurrent: l.cfg.MaxConcurrent,
+	})
+}
+
+func timeoutResult(tool string, timeout time.Duration) *mcp.CallToolResult {
+	return errorResult(Error{
+		Reason:  ReasonTimeout,
+		Tool:    tool,
+		Message: fmt.Sprintf("%s timed out after %s", tool, timeout),
+		Timeout: timeout.String(),
+	})
+}
+
+func errorResult(e Error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: e.Message}},
+		StructuredContent: e,
+		IsError:           true,
+	}
+}
@@ -0,0 +1,194 @@
+package limits
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callRequest(tool string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tool}}
+}
+
+func okResult() *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}
+}
+
+func TestParseToolTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		values      []string
+		expected    map[string]time.Duration
+		expectedErr string
+	}{
+		{
+			name:     "none",
+			values:   nil,
+			expected: nil,
+		},
+		{
+			name:     "several tools",
+			values:   []string{"search_code=2m", " download_workflow_run_artifact = 30s "},
+			expected: map[string]time.Duration{"search_code": 2 * time.Minute, "download_workflow_run_artifact": 30 * time.Second},
+		},
+		{
+			name:     "zero exempts a tool",
+			values:   []string{"push_files=0s"},
+			expected: map[string]time.Duration{"push_files": 0},
+		},
+		{
+			name:        "missing duration",
+			values:      []string{"search_code"},
+			expectedErr: `invalid tool timeout "search_code": expected tool=duration`,
+		},
+		{
+			name:        "invalid duration",
+			values:      []string{"search_code=soon"},
+			expectedErr: `invalid tool timeout "search_code=soon"`,
+		},
+		{
+			name:        "negative duration",
+			values:      []string{"search_code=-1s"},
+			expectedErr: "must not be negative",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			timeouts, err := ParseToolTimeouts(tc.values)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, timeouts)
+		})
+	}
+}
+
+func TestMiddleware_Timeout(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Config{
+		Timeout:      20 * time.Millisecond,
+		ToolTimeouts: map[string]time.Duration{"slow_tool": 0},
+	})
+
+	// The handler ignores its context, as a stuck handler would
+	release := make(chan struct{})
+	defer close(release)
+	handler := limiter.Middleware()(func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		if call, ok := req.(*mcp.CallToolRequest); ok && call.Params.Name == "stuck_tool" {
+			<-release
+		}
+		return okResult(), nil
+	})
+
+	result, err := handler(context.Background(), "tools/call", callRequest("stuck_tool"))
+	require.NoError(t, err)
+	callResult := result.(*mcp.CallToolResult)
+	assert.True(t, callResult.IsError)
+	assert.Equal(t, Error{
+		Reason:  ReasonTimeout,
+		Tool:    "stuck_tool",
+		Message: "stuck_tool timed out after 20ms",
+		Timeout: "20ms",
+	}, callResult.StructuredContent)
+
+	// Exempt tools and other methods are not limited
+	result, err = handler(context.Background(), "tools/call", callRequest("slow_tool"))
+	require.NoError(t, err)
+	assert.False(t, result.(*mcp.CallToolResult).IsError)
+	result, err = handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestMiddleware_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Config{Timeout: time.Minute})
+	handler := limiter.Middleware()(func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := handler(ctx, "tools/call", callRequest("get_me"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMiddleware_MaxConcurrent(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Config{MaxConcurrent: 1, ToolTimeouts: map[string]time.Duration{"waiting_tool": 50 * time.Millisecond}})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := limiter.Middleware()(func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		if req.(*mcp.CallToolRequest).Params.Name == "busy_tool" {
+			close(started)
+			<-release
+		}
+		return okResult(), nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = handler(context.Background(), "tools/call", callRequest("busy_tool"))
+	}()
+	<-started
+
+	// Without a timeout a call does not wait for a slot
+	result, err := handler(context.Background(), "tools/call", callRequest("get_me"))
+	require.NoError(t, err)
+	callResult := result.(*mcp.CallToolResult)
+	assert.True(t, callResult.IsError)
+	assert.Equal(t, Error{
+		Reason:        ReasonConcurrency,
+		Tool:          "get_me",
+		Message:       "get_me was not run: the server is already running 1 tool calls, try again later",
+		MaxConcurrent: 1,
+	}, callResult.StructuredContent)
+
+	// With a timeout a call waits for a slot until the timeout expires
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	result, err = handler(context.Background(), "tools/call", callRequest("waiting_tool"))
+	require.NoError(t, err)
+	assert.False(t, result.(*mcp.CallToolResult).IsError)
+	wg.Wait()
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tools := []inventory.ServerTool{{Tool: mcp.Tool{Name: "search_code"}}, {Tool: mcp.Tool{Name: "push_files"}}}
+
+	assert.NoError(t, Config{}.Validate(tools))
+	assert.NoError(t, Config{ToolTimeouts: map[string]time.Duration{"search_code": time.Minute, "push_files": 0}}.Validate(tools))
+	assert.ErrorContains(t, Config{ToolTimeouts: map[string]time.Duration{"serch_code": time.Minute}}.Validate(tools), `"serch_code": no such tool`)
+}
+
+func TestConfig_Enabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Config{}.Enabled())
+	assert.True(t, Config{Timeout: time.Second}.Enabled())
+	assert.True(t, Config{ToolTimeouts: map[string]time.Duration{"get_me": time.Second}}.Enabled())
+	assert.True(t, Config{MaxConcurrent: 4}.Enabled())
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/muesli/cache2go"
@@ -21,6 +22,10 @@ type RepoAccessCache struct {
 	ttl              time.Duration
 	logger           *slog.Logger
 	trustedBotLogins map[string]struct{}
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
 }
 
 type repoAccessCacheEntry struct {
@@ -95,10 +100,18 @@ func GetInstance(client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessC
 				opt(instance)
 			}
 		}
+		instance.countEvictions()
 	}
 	return instance
 }
 
+// countEvictions counts entries leaving the cache table, whether expired or replaced by a new table.
+func (c *RepoAccessCache) countEvictions() {
+	c.cache.SetAboutToDeleteItemCallback(func(_ *cache2go.CacheItem) {
+		c.evictions.Add(1)
+	})
+}
+
 // SetLogger updates the logger used for cache diagnostics.
 func (c *RepoAccessCache) SetLogger(logger *slog.Logger) {
 	c.mu.Lock()
@@ -113,6 +126,15 @@ type CacheStats struct {
 	Evictions int64
 }
 
+// Stats returns the activity counters of the cache since it was created.
+func (c *RepoAccessCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
 // IsSafeContent determines if the specified user can safely access the requested repository content.
 // Safe access applies when any of the following is true:
 // - the content was created by a trusted bot;
@@ -149,6 +171,7 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 	if err == nil {
 		entry := cacheItem.Data().(*repoAccessCacheEntry)
 		if cachedHasPush, known := entry.knownUsers[userKey]; known {
+			c.hits.Add(1)
 			c.logDebug(ctx, fmt.Sprintf("repo access cache hit for user %s to %s/%s", username, owner, repo))
 			return RepoAccessInfo{
 				IsPrivate:     entry.isPrivate,
@@ -157,6 +180,7 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 			}, nil
 		}
 
+		c.misses.Add(1)
 		c.logDebug(ctx, "known users cache miss, fetching from graphql API")
 
 		info, queryErr := c.queryRepoAccessInfo(ctx, username, owner, repo)
@@ -176,6 +200,7 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 		}, nil
 	}
 
+	c.misses.Add(1)
 	c.logDebug(ctx, fmt.Sprintf("repo access cache miss for user %s to %s/%s", username, owner, repo))
 
 	info, queryErr := c.queryRepoAccessInfo(ctx, username, owner, repo)
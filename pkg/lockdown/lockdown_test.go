@@ -109,4 +109,13 @@ func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
 	require.Equal(t, testUser, info.ViewerLogin)
 	require.True(t, info.HasPushAccess)
 	require.EqualValues(t, 2, transport.CallCount())
+
+	_, err = cache.getRepoAccessInfo(ctx, testUser, testOwner, testRepo)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, transport.CallCount())
+
+	stats := cache.Stats()
+	require.EqualValues(t, 1, stats.Hits)
+	require.EqualValues(t, 2, stats.Misses)
+	require.EqualValues(t, 1, stats.Evictions)
 }
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Directions of a logged message, relative to the server.
+const (
+	DirectionReceived = "received"
+	DirectionSent     = "sent"
+)
+
+// ProtocolTransport is an mcp.Transport that logs every JSON-RPC message passing over the
+// underlying transport as one entry, with redaction applied. Responses are correlated with
+// their request, so their entries carry the method and how long the request took.
+type ProtocolTransport struct {
+	// Transport is the underlying transport.
+	Transport mcp.Transport
+
+	// Logger receives the entries.
+	Logger *slog.Logger
+
+	// Redaction is applied to params, results and error data before they are logged.
+	Redaction Redaction
+}
+
+// Connect implements mcp.Transport.
+func (t *ProtocolTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	conn, err := t.Transport.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &protocolConnection{
+		Connection: conn,
+		logger:     t.Logger,
+		redaction:  t.Redaction,
+		pending:    make(map[pendingKey]pendingRequest),
+	}, nil
+}
+
+// pendingKey identifies an outstanding request by the direction it was sent in and its ID,
+// as the client and the server number their requests independently.
+type pendingKey struct {
+	direction string
+	id        any
+}
+
+type pendingRequest struct {
+	method string
+	start  time.Time
+}
+
+type protocolConnection struct {
+	mcp.Connection
+	logger    *slog.Logger
+	redaction Redaction
+
+	mu      sync.Mutex
+	pending map[pendingKey]pendingRequest
+}
+
+func (c *protocolConnection) Read(ctx context.Context) (jsonrpc.Message, error) {
+	msg, err := c.Connection.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.log(DirectionReceived, msg)
+	return msg, nil
+}
+
+func (c *protocolConnection) Write(ctx context.Context, msg jsonrpc.Message) error {
+	c.log(DirectionSent, msg)
+	return c.Connection.Write(ctx, msg)
+}
+
+func (c *protocolConnection) log(direction string, msg jsonrpc.Message) {
+	attrs := []any{"direction", direction}
+
+	switch msg := msg.(type) {
+	case *jsonrpc.Request:
+		attrs = append(attrs, "method", msg.Method)
+		if !msg.ID.IsValid() {
+			attrs = append(attrs, "type", "notification")
+		} else {
+			attrs = append(attrs, "type", "request", "id", msg.ID.Raw())
+			c.mu.Lock()
+			c.pending[pendingKey{direction, msg.ID.Raw()}] = pendingRequest{method: msg.Method, start: time.Now()}
+			c.mu.Unlock()
+		}
+		if len(msg.Params) > 0 {
+			attrs = append(attrs, "params", string(c.redaction.Redact(msg.Params)))
+		}
+
+	case *jsonrpc.Response:
+		attrs = append(attrs, "type", "response", "id", msg.ID.Raw())
+		// A response travels the opposite way of its request
+		requestDirection := DirectionSent
+		if direction == DirectionSent {
+			requestDirection = DirectionReceived
+		}
+		key := pendingKey{requestDirection, msg.ID.Raw()}
+		c.mu.Lock()
+		request, ok := c.pending[key]
+		delete(c.pending, key)
+		c.mu.Unlock()
+		if ok {
+			attrs = append(attrs, "method", request.method, "duration", time.Since(request.start))
+		}
+		if msg.Error != nil {
+			attrs = append(attrs, "error", c.errorString(msg.Error))
+		} else if len(msg.Result) > 0 {
+			attrs = append(attrs, "result", string(c.redaction.Redact(msg.Result)))
+		}
+
+	default:
+		attrs = append(attrs, "type", fmt.Sprintf("%T", msg))
+	}
+
+	c.logger.Info("mcp message", attrs...)
+}
+
+func (c *protocolConnection) errorString(err error) string {
+	var wireErr *jsonrpc.Error
+	if !errors.As(err, &wireErr) {
+		return err.Error()
+	}
+	data, _ := json.Marshal(struct {
+		Code    int64           `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}{wireErr.Code, gitHubTokenPattern.ReplaceAllString(wireErr.Message, Redacted), c.redaction.Redact(wireErr.Data)})
+	return string(data)
+}
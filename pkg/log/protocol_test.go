@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport hands out a connection that reads the given messages and records writes.
+type fakeTransport struct {
+	conn *fakeConnection
+}
+
+func (t *fakeTransport) Connect(_ context.Context) (mcp.Connection, error) {
+	return t.conn, nil
+}
+
+type fakeConnection struct {
+	incoming []jsonrpc.Message
+	written  []jsonrpc.Message
+}
+
+func (c *fakeConnection) Read(_ context.Context) (jsonrpc.Message, error) {
+	if len(c.incoming) == 0 {
+		return nil, io.EOF
+	}
+	msg := c.incoming[0]
+	c.incoming = c.incoming[1:]
+	return msg, nil
+}
+
+func (c *fakeConnection) Write(_ context.Context, msg jsonrpc.Message) error {
+	c.written = append(c.written, msg)
+	return nil
+}
+
+func (c *fakeConnection) Close() error      { return nil }
+func (c *fakeConnection) SessionID() string { return "" }
+
+func decode(t *testing.T, data string) jsonrpc.Message {
+	t.Helper()
+	msg, err := jsonrpc.DecodeMessage([]byte(data))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestProtocolTransport(t *testing.T) {
+	t.Parallel()
+
+	inner := &fakeConnection{incoming: []jsonrpc.Message{
+		decode(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_me","arguments":{"token":"secret"}}}`),
+		decode(t, `{"jsonrpc":"2.0","method":"notifications/initialized"}`),
+	}}
+	var buf bytes.Buffer
+	transport := &ProtocolTransport{
+		Transport: &fakeTransport{conn: inner},
+		Logger:    slog.New(slog.NewJSONHandler(&buf, nil)),
+	}
+
+	conn, err := transport.Connect(context.Background())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	request, err := conn.Read(ctx)
+	require.NoError(t, err)
+	_, err = conn.Read(ctx)
+	require.NoError(t, err)
+	require.NoError(t, conn.Write(ctx, decode(t, `{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"octocat"}]}}`)))
+	require.NoError(t, conn.Write(ctx, decode(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"unknown tool"}}`)))
+
+	// Messages pass through unchanged, only the log is redacted
+	assert.Contains(t, string(request.(*jsonrpc.Request).Params), `"token":"secret"`)
+	assert.Len(t, inner.written, 2)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	entries := make([]map[string]any, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+	}
+
+	assert.Equal(t, DirectionReceived, entries[0]["direction"])
+	assert.Equal(t, "request", entries[0]["type"])
+	assert.Equal(t, "tools/call", entries[0]["method"])
+	assert.Equal(t, float64(1), entries[0]["id"])
+	assert.JSONEq(t, `{"name":"get_me","arguments":{"token":"[REDACTED]"}}`, entries[0]["params"].(string))
+
+	assert.Equal(t, "notification", entries[1]["type"])
+	assert.Equal(t, "notifications/initialized", entries[1]["method"])
+
+	// The response is correlated with the request it answers
+	assert.Equal(t, DirectionSent, entries[2]["direction"])
+	assert.Equal(t, "response", entries[2]["type"])
+	assert.Equal(t, "tools/call", entries[2]["method"])
+	assert.Contains(t, entries[2], "duration")
+	assert.JSONEq(t, `{"content":[{"type":"text","text":"octocat"}]}`, entries[2]["result"].(string))
+
+	// A response to an unknown request is logged without a method
+	assert.NotContains(t, entries[3], "method")
+	assert.JSONEq(t, `{"code":-32602,"message":"unknown tool"}`, entries[3]["error"].(string))
+}
@@ -0,0 +1,109 @@
+package log
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultRedactKeys are the field names whose values are always redacted. Keys match
+// case-insensitively when they contain one of these words.
+var DefaultRedactKeys = []string{"token", "secret", "password", "authorization", "private_key", "cookie"}
+
+// DefaultMaxStringLength is the longest string value logged in full by default.
+const DefaultMaxStringLength = 1024
+
+// Redacted replaces values removed from logged messages.
+const Redacted = "[REDACTED]"
+
+// gitHubTokenPattern matches GitHub tokens wherever they appear in a string, such as
+// personal access tokens pasted into an issue body.
+var gitHubTokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// contentKeys are the fields that carry file and resource contents in MCP messages.
+var contentKeys = map[string]bool{"text": true, "blob": true, "content": true}
+
+// Redaction configures what is removed from messages before they are logged.
+type Redaction struct {
+	// Keys are field names whose values are redacted, in addition to DefaultRedactKeys.
+	Keys []string
+
+	// MaxStringLength truncates longer string values. Zero logs strings in full.
+	MaxStringLength int
+
+	// TruncateContent replaces text, blob and content fields with their length.
+	TruncateContent bool
+}
+
+// Redact returns a copy of the JSON value data with the redaction rules applied. Data that
+// is not valid JSON is treated as a single string.
+func (r Redaction) Redact(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return data
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		value = string(data)
+	}
+	redacted, err := json.Marshal(r.redactValue("", value))
+	if err != nil {
+		return json.RawMessage(strconv.Quote(Redacted))
+	}
+	return redacted
+}
+
+func (r Redaction) redactValue(key string, value any) any {
+	if key != "" && r.isSecretKey(key) {
+		return Redacted
+	}
+	switch v := value.(type) {
+	case string:
+		if r.TruncateContent && contentKeys[key] {
+			return "[" + strconv.Itoa(len(v)) + " bytes]"
+		}
+		return r.RedactString(v)
+	case map[string]any:
+		for k, inner := range v {
+			v[k] = r.redactValue(k, inner)
+		}
+		return v
+	case []any:
+		for i, inner := range v {
+			v[i] = r.redactValue(key, inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// RedactString returns s with GitHub tokens redacted, truncated to MaxStringLength.
+func (r Redaction) RedactString(s string) string {
+	return r.truncate(gitHubTokenPattern.ReplaceAllString(s, Redacted))
+}
+
+func (r Redaction) isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, words := range [][]string{DefaultRedactKeys, r.Keys} {
+		for _, word := range words {
+			if word != "" && strings.Contains(key, strings.ToLower(word)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncate cuts s to MaxStringLength bytes without splitting a rune, noting the original length.
+func (r Redaction) truncate(s string) string {
+	if r.MaxStringLength <= 0 || len(s) <= r.MaxStringLength {
+		return s
+	}
+	cut := r.MaxStringLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…[truncated, " + strconv.Itoa(len(s)) + " bytes]"
+}
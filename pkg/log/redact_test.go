@@ -0,0 +1,67 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	token := "ghp_" + strings.Repeat("a", 36)
+	tests := []struct {
+		name      string
+		redaction Redaction
+		input     string
+		expected  string
+	}{
+		{
+			name:     "secret keys are redacted at any depth",
+			input:    `{"github_token":"abc","nested":{"Authorization":"Bearer abc","list":[{"client_secret":"x"}]},"count":2}`,
+			expected: `{"count":2,"github_token":"[REDACTED]","nested":{"Authorization":"[REDACTED]","list":[{"client_secret":"[REDACTED]"}]}}`,
+		},
+		{
+			name:     "tokens inside strings are redacted",
+			input:    `{"body":"my token is ` + token + `, oops"}`,
+			expected: `{"body":"my token is [REDACTED], oops"}`,
+		},
+		{
+			name:      "configured keys are redacted",
+			redaction: Redaction{Keys: []string{"Email"}},
+			input:     `{"user_email":"octocat@github.com","login":"octocat"}`,
+			expected:  `{"login":"octocat","user_email":"[REDACTED]"}`,
+		},
+		{
+			name:      "long strings are truncated",
+			redaction: Redaction{MaxStringLength: 4},
+			input:     `{"title":"abcdefgh"}`,
+			expected:  `{"title":"abcd…[truncated, 8 bytes]"}`,
+		},
+		{
+			name:      "truncation keeps runes whole",
+			redaction: Redaction{MaxStringLength: 4},
+			input:     `{"title":"abcé"}`,
+			expected:  `{"title":"abc…[truncated, 5 bytes]"}`,
+		},
+		{
+			name:      "content fields are replaced by their length",
+			redaction: Redaction{TruncateContent: true},
+			input:     `{"content":[{"type":"text","text":"hello world"}]}`,
+			expected:  `{"content":[{"text":"[11 bytes]","type":"text"}]}`,
+		},
+		{
+			name:     "invalid JSON is treated as a string",
+			input:    `not json ` + token,
+			expected: `"not json [REDACTED]"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.JSONEq(t, tc.expected, string(tc.redaction.Redact(json.RawMessage(tc.input))))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Package metrics records Prometheus metrics for tool calls, GitHub API requests and caches.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name.
+const namespace = "github_mcp"
+
+// unknownTool is the tool label of calls of tools the server does not have.
+const unknownTool = "unknown"
+
+// Outcomes of a tool call, reported in the outcome label.
+const (
+	OutcomeSuccess   = "success"
+	OutcomeToolError = "tool_error"
+	OutcomeError     = "error"
+)
+
+// Kinds of GitHub errors collected during tool calls, reported in the kind label.
+const (
+	errorKindREST    = "rest"
+	errorKindGraphQL = "graphql"
+	errorKindRaw     = "raw"
+)
+
+// Metrics holds the collectors of the server in their own registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls        *prometheus.CounterVec
+	toolCallDuration *prometheus.HistogramVec
+	apiRequests      *prometheus.CounterVec
+	apiDuration      *prometheus.HistogramVec
+	githubErrors     *prometheus.CounterVec
+
+	repoAccessCache atomic.Pointer[lockdown.RepoAccessCache]
+}
+
+// New creates the collectors and registers them, along with the Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Tool calls by tool and outcome (success, tool_error or error).",
+		}, []string{"tool", "outcome"}),
+		toolCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Duration of tool calls by tool.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"tool"}),
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "github_requests_total",
+			Help:      "GitHub API requests by API (rest, graphql or raw) and HTTP status code, or \"error\" when no response was received.",
+		}, []string{"api", "code"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "github_request_duration_seconds",
+			Help:      "Duration of GitHub API requests by API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"api"}),
+		githubErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "github_errors_total",
+			Help:      "GitHub errors returned to tools, by kind (rest, graphql or raw) and HTTP status code if known.",
+		}, []string{"kind", "code"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.toolCalls,
+		m.toolCallDuration,
+		m.apiRequests,
+		m.apiDuration,
+		m.githubErrors,
+		m.repoAccessCacheCounter("hits_total", "Lockdown repository access cache hits.", func(s lockdown.CacheStats) int64 { return s.Hits }),
+		m.repoAccessCacheCounter("misses_total", "Lockdown repository access cache misses.", func(s lockdown.CacheStats) int64 { return s.Misses }),
+		m.repoAccessCacheCounter("evictions_total", "Lockdown repository access cache evictions.", func(s lockdown.CacheStats) int64 { return s.Evictions }),
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRepoAccessCache reports the statistics of the lockdown repository access cache.
+func (m *Metrics) ObserveRepoAccessCache(cache *lockdown.RepoAccessCache) {
+	m.repoAccessCache.Store(cache)
+}
+
+func (m *Metrics) repoAccessCacheCounter(name, help string, value func(lockdown.CacheStats) int64) prometheus.Collector {
+	return prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "repo_access_cache",
+		Name:      name,
+		Help:      help,
+	}, func() float64 {
+		cache := m.repoAccessCache.Load()
+		if cache == nil {
+			return 0
+		}
+		return float64(value(cache.Stats()))
+	})
+}
+
+// Middleware returns receiving middleware for an mcp.Server that records tool calls, and the
+// GitHub errors collected while handling them. It must run inside the middleware that adds
+// the GitHub errors to the context, so that it can read them. Calls are labeled with the name
+// of the tool if it is in inv or in extraTools, and as "unknown" otherwise, so that clients
+// cannot create a series for every name they call.
+func (m *Metrics) Middleware(inv *inventory.Inventory, extraTools ...string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callRequest.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+			tool := callRequest.Params.Name
+			if _, _, findErr := inv.FindToolByName(tool); findErr != nil && !slices.Contains(extraTools, tool) {
+				tool = unknownTool
+			}
+
+			outcome := OutcomeSuccess
+			if err != nil {
+				outcome = OutcomeError
+			} else if callResult, ok := result.(*mcp.CallToolResult); ok && callResult != nil && callResult.IsError {
+				outcome = OutcomeToolError
+			}
+			m.toolCalls.WithLabelValues(tool, outcome).Inc()
+			m.toolCallDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+			m.recordGitHubErrors(ctx)
+
+			return result, err
+		}
+	}
+}
+
+// recordGitHubErrors counts the GitHub errors that tool handlers added to the context.
+func (m *Metrics) recordGitHubErrors(ctx context.Context) {
+	if apiErrors, err := ghErrors.GetGitHubAPIErrors(ctx); err == nil {
+		for _, apiErr := range apiErrors {
+			code := ""
+			if apiErr.Response != nil {
+				code = strconv.Itoa(apiErr.Response.StatusCode)
+			}
+			m.githubErrors.WithLabelValues(errorKindREST, code).Inc()
+		}
+	}
+	if gqlErrors, err := ghErrors.GetGitHubGraphQLErrors(ctx); err == nil {
+		for range gqlErrors {
+			m.githubErrors.WithLabelValues(errorKindGraphQL, "").Inc()
+		}
+	}
+	if rawErrors, err := ghErrors.GetGitHubRawAPIErrors(ctx); err == nil {
+		for _, rawErr := range rawErrors {
+			code := ""
+			if rawErr.Response != nil {
+				code = strconv.Itoa(rawErr.Response.StatusCode)
+			}
+			m.githubErrors.WithLabelValues(errorKindRaw, code).Inc()
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	inv := inventory.NewBuilder().SetTools([]inventory.ServerTool{
+		inventory.NewServerToolFromHandler(
+			mcp.Tool{Name: "issue_read", InputSchema: json.RawMessage(`{"type":"object"}`)},
+			inventory.ToolsetMetadata{ID: "issues"},
+			func(_ any) mcp.ToolHandler { return nil },
+		),
+	}).Build()
+	calls := map[string]mcp.MethodHandler{
+		"success": func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			return &mcp.CallToolResult{}, nil
+		},
+		"tool_error": func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, errors.New("not found"))
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", errors.New("boom")), nil
+		},
+		"error": func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	for name, next := range calls {
+		ctx := ghErrors.ContextWithGitHubErrors(context.Background())
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "issue_read"}}
+		_, _ = m.Middleware(inv)(next)(ctx, "tools/call", req)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.toolCalls.WithLabelValues("issue_read", name)), name)
+	}
+
+	// Requests other than tool calls are not counted
+	_, err := m.Middleware(inv)(calls["success"])(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, testutil.CollectAndCount(m.toolCalls))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.githubErrors))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.githubErrors.WithLabelValues(errorKindREST, "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.githubErrors.WithLabelValues(errorKindGraphQL, "")))
+}
+
+func TestMiddleware_UnknownTools(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	inv := inventory.NewBuilder().Build()
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{IsError: true}, nil
+	}
+
+	// Names the server does not have share one series, other than the extra tools
+	for _, name := range []string{"made_up_1", "made_up_2", "enable_toolset"} {
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}}
+		_, err := m.Middleware(inv, "enable_toolset")(next)(context.Background(), "tools/call", req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.toolCalls))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.toolCalls.WithLabelValues(unknownTool, OutcomeToolError)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.toolCalls.WithLabelValues("enable_toolset", OutcomeToolError)))
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/issues/404" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	rawURL, err := url.Parse(ts.URL + "/raw/")
+	require.NoError(t, err)
+
+	m := New()
+	client := &http.Client{Transport: &Transport{Metrics: m, RawURL: rawURL}}
+	for _, path := range []string{"/repos/owner/repo/issues/1", "/repos/owner/repo/issues/404", "/graphql", "/raw/owner/repo/HEAD/README.md"} {
+		resp, err := client.Get(ts.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues("rest", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues("rest", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues("graphql", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues("raw", "200")))
+
+	// Requests that get no response are counted as errors
+	ts.Close()
+	_, err = client.Get(ts.URL + "/repos/owner/repo")
+	require.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues("rest", "error")))
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	m.toolCalls.WithLabelValues("get_me", OutcomeSuccess).Inc()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `github_mcp_tool_calls_total{outcome="success",tool="get_me"} 1`)
+	// The lockdown cache counters are reported even before a cache is observed
+	assert.Contains(t, string(body), "github_mcp_repo_access_cache_hits_total 0")
+	assert.Contains(t, string(body), "go_goroutines")
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+)
+
+// Transport is an http.RoundTripper that records the status code and duration of every
+// GitHub API request.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Metrics records the requests.
+	Metrics *Metrics
+
+	// RawURL is the base URL of the raw content API, to tell raw requests apart from REST requests.
+	RawURL *url.URL
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	api := utils.APIFromURL(req.URL, t.RawURL)
+	start := time.Now()
+
+	resp, err := t.base().RoundTrip(req)
+
+	t.Metrics.apiDuration.WithLabelValues(api).Observe(time.Since(start).Seconds())
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.Metrics.apiRequests.WithLabelValues(api, code).Inc()
+	return resp, err
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
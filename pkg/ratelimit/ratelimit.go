@@ -0,0 +1,151 @@
+// Package ratelimit tracks GitHub API rate limit budgets and waits out rate limits in an HTTP transport
+package ratelimit
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v79/github"
+)
+
+// Rate limit resources as reported in the X-RateLimit-Resource header.
+const (
+	ResourceCore       = "core"
+	ResourceSearch     = "search"
+	ResourceCodeSearch = "code_search"
+	ResourceGraphQL    = "graphql"
+)
+
+// Rate limit response headers.
+// See https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api#checking-the-status-of-your-rate-limit
+const (
+	headerLimit      = "X-RateLimit-Limit"
+	headerRemaining  = "X-RateLimit-Remaining"
+	headerUsed       = "X-RateLimit-Used"
+	headerReset      = "X-RateLimit-Reset"
+	headerResource   = "X-RateLimit-Resource"
+	headerRetryAfter = "Retry-After"
+)
+
+// Budget is the state of the rate limit for one resource.
+type Budget struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Tracker records the latest rate limit budget seen for each resource. It is safe for concurrent use.
+type Tracker struct {
+	mu      sync.RWMutex
+	budgets map[string]Budget
+}
+
+// NewTracker creates an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{budgets: make(map[string]Budget)}
+}
+
+// Update records the budget for its resource.
+func (t *Tracker) Update(b Budget) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.budgets[b.Resource] = b
+}
+
+// UpdateFromRateLimits records the budgets returned by the rate limit API.
+func (t *Tracker) UpdateFromRateLimits(limits *gogithub.RateLimits) {
+	for resource, rate := range map[string]*gogithub.Rate{
+		ResourceCore:       limits.GetCore(),
+		ResourceSearch:     limits.GetSearch(),
+		ResourceCodeSearch: limits.GetCodeSearch(),
+		ResourceGraphQL:    limits.GetGraphQL(),
+	} {
+		if rate == nil {
+			continue
+		}
+		t.Update(Budget{
+			Resource:  resource,
+			Limit:     rate.Limit,
+			Remaining: rate.Remaining,
+			Used:      rate.Used,
+			Reset:     rate.Reset.Time,
+		})
+	}
+}
+
+// Budget returns the latest budget seen for a resource.
+func (t *Tracker) Budget(resource string) (Budget, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	b, ok := t.budgets[resource]
+	return b, ok
+}
+
+// Budgets returns the latest budget of every resource seen, ordered by resource.
+func (t *Tracker) Budgets() []Budget {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	budgets := make([]Budget, 0, len(t.budgets))
+	for _, b := range t.budgets {
+		budgets = append(budgets, b)
+	}
+	slices.SortFunc(budgets, func(a, b Budget) int { return strings.Compare(a.Resource, b.Resource) })
+	return budgets
+}
+
+// updateFromResponse records the budget from the rate limit headers of a response, if present.
+func (t *Tracker) updateFromResponse(resp *http.Response) {
+	budget, ok := budgetFromHeaders(resp.Header)
+	if !ok {
+		return
+	}
+	if budget.Resource == "" {
+		budget.Resource = resourceForRequest(resp.Request)
+	}
+	t.Update(budget)
+}
+
+func budgetFromHeaders(h http.Header) (Budget, bool) {
+	remaining, err := strconv.Atoi(h.Get(headerRemaining))
+	if err != nil {
+		return Budget{}, false
+	}
+	limit, _ := strconv.Atoi(h.Get(headerLimit))
+	used, _ := strconv.Atoi(h.Get(headerUsed))
+	var reset time.Time
+	if epoch, err := strconv.ParseInt(h.Get(headerReset), 10, 64); err == nil {
+		reset = time.Unix(epoch, 0)
+	}
+	return Budget{
+		Resource:  h.Get(headerResource),
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     reset,
+	}, true
+}
+
+// resourceForRequest guesses the rate limit resource a request counts against, for use
+// before the response tells us.
+func resourceForRequest(req *http.Request) string {
+	if req == nil {
+		return ResourceCore
+	}
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/graphql"):
+		return ResourceGraphQL
+	case strings.Contains(path, "/search/code"):
+		return ResourceCodeSearch
+	case strings.Contains(path, "/search/"):
+		return ResourceSearch
+	default:
+		return ResourceCore
+	}
+}
@@ -0,0 +1,149 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// secondaryLimitBackoff is the first wait after a secondary rate limit without a Retry-After
+	// header. GitHub asks clients to wait at least a minute, doubling on repeated limits.
+	secondaryLimitBackoff = time.Minute
+
+	// maxRetries bounds how often a single request is retried after being rate limited.
+	maxRetries = 3
+
+	// maxErrorBodySize bounds how much of a 403 response body is read to detect a secondary rate limit.
+	maxErrorBodySize = 64 << 10
+)
+
+// Transport is an http.RoundTripper that records rate limit budgets in a Tracker, and waits
+// for rate limits to clear before retrying, as long as the total wait stays within MaxWait.
+// A rate limited response that would need a longer wait is returned to the caller as is.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Tracker records the budget from every response.
+	Tracker *Tracker
+
+	// MaxWait is the longest a single request may spend waiting for rate limits.
+	// Zero disables waiting.
+	MaxWait time.Duration
+
+	// sleep is stubbed in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var waited time.Duration
+
+	// Don't spend a request we know will be rejected if the budget resets soon enough
+	if budget, ok := t.Tracker.Budget(resourceForRequest(req)); ok && budget.Remaining == 0 {
+		if wait := time.Until(budget.Reset); wait > 0 && wait <= t.MaxWait {
+			if err := t.wait(req.Context(), wait); err != nil {
+				return nil, err
+			}
+			waited += wait
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			req = req.Clone(req.Context())
+			// Requests without a body, such as GETs, have no GetBody and are sent again as is
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err := t.base().RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.Tracker.updateFromResponse(resp)
+
+		wait, limited := retryDelay(resp, attempt)
+		canRetry := req.Body == nil || req.GetBody != nil
+		if !limited || !canRetry || attempt >= maxRetries || waited+wait > t.MaxWait {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		waited += wait
+	}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *Transport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryDelay reports whether a response was rejected by a primary or secondary rate limit,
+// and how long to wait before retrying.
+// See https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+func retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get(headerRetryAfter)); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if resp.Header.Get(headerRemaining) == "0" {
+		if epoch, err := strconv.ParseInt(resp.Header.Get(headerReset), 10, 64); err == nil {
+			// Add a second, as the reset time is truncated to whole seconds
+			return time.Until(time.Unix(epoch, 0)) + time.Second, true
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || isSecondaryRateLimit(resp) {
+		return secondaryLimitBackoff << attempt, true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit checks the start of the body of a 403 response for the secondary rate
+// limit message. The body is replaced so that the caller can still read all of it, and close it.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
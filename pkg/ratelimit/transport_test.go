@@ -0,0 +1,242 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordSleeps returns a sleep function that records the requested waits instead of sleeping.
+func recordSleeps(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+}
+
+func TestTransport_TracksBudgets(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := ResourceCore
+		if r.URL.Path == "/graphql" {
+			resource = ResourceGraphQL
+		}
+		w.Header().Set(headerLimit, "5000")
+		w.Header().Set(headerRemaining, "4321")
+		w.Header().Set(headerUsed, "679")
+		w.Header().Set(headerReset, strconv.FormatInt(reset.Unix(), 10))
+		w.Header().Set(headerResource, resource)
+	}))
+	defer ts.Close()
+
+	tracker := NewTracker()
+	client := &http.Client{Transport: &Transport{Tracker: tracker}}
+
+	for _, path := range []string{"/repos/o/r", "/graphql"} {
+		resp, err := client.Get(ts.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	budgets := tracker.Budgets()
+	require.Len(t, budgets, 2)
+	assert.Equal(t, ResourceCore, budgets[0].Resource)
+	assert.Equal(t, ResourceGraphQL, budgets[1].Resource)
+	assert.Equal(t, Budget{Resource: ResourceCore, Limit: 5000, Remaining: 4321, Used: 679, Reset: reset}, budgets[0])
+}
+
+func TestTransport_RetriesSecondaryRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		limitResponse func(w http.ResponseWriter)
+		maxWait       time.Duration
+		expectedWaits []time.Duration
+		expectedCode  int
+	}{
+		{
+			name: "retry after header",
+			limitResponse: func(w http.ResponseWriter) {
+				w.Header().Set(headerRetryAfter, "5")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			maxWait:       time.Minute,
+			expectedWaits: []time.Duration{5 * time.Second},
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name: "secondary rate limit message backs off",
+			limitResponse: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"message": "You have exceeded a secondary rate limit."}`)
+			},
+			maxWait:       2 * time.Minute,
+			expectedWaits: []time.Duration{time.Minute},
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name: "wait beyond ceiling returns the limited response",
+			limitResponse: func(w http.ResponseWriter) {
+				w.Header().Set(headerRetryAfter, "120")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			maxWait:      time.Minute,
+			expectedCode: http.StatusTooManyRequests,
+		},
+		{
+			name: "permission errors are not retried",
+			limitResponse: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"message": "Resource not accessible by integration"}`)
+			},
+			maxWait:      time.Minute,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					tc.limitResponse(w)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(body)
+			}))
+			defer ts.Close()
+
+			var waits []time.Duration
+			client := &http.Client{Transport: &Transport{
+				Tracker: NewTracker(),
+				MaxWait: tc.maxWait,
+				sleep:   recordSleeps(&waits),
+			}}
+
+			resp, err := client.Post(ts.URL, "application/json", strings.NewReader(`{"query": "q"}`))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedWaits, waits)
+			if tc.expectedCode == http.StatusOK {
+				// The body is sent again on retry
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, `{"query": "q"}`, string(body))
+			}
+		})
+	}
+}
+
+func TestTransport_RetriesSecondaryRateLimitWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set(headerRetryAfter, "5")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, r.Method)
+	}))
+	defer ts.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: &Transport{
+		Tracker: NewTracker(),
+		MaxWait: time.Minute,
+		sleep:   recordSleeps(&waits),
+	}}
+
+	resp, err := client.Get(ts.URL + "/repos/o/r")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{5 * time.Second}, waits)
+	assert.Equal(t, int32(2), requests.Load())
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodGet, string(body))
+}
+
+func TestTransport_KeepsLargeForbiddenBody(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("x", maxErrorBodySize+1000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, body)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Tracker: NewTracker(), MaxWait: time.Hour}}
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	got, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, body, string(got))
+}
+
+func TestTransport_WaitsForExhaustedBudget(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer ts.Close()
+
+	tracker := NewTracker()
+	tracker.Update(Budget{Resource: ResourceSearch, Remaining: 0, Reset: time.Now().Add(10 * time.Second)})
+
+	var waits []time.Duration
+	client := &http.Client{Transport: &Transport{
+		Tracker: tracker,
+		MaxWait: time.Minute,
+		sleep:   recordSleeps(&waits),
+	}}
+
+	resp, err := client.Get(ts.URL + "/search/issues?q=bug")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Len(t, waits, 1)
+	assert.InDelta(t, 10*time.Second, waits[0], float64(time.Second))
+}
+
+func TestResourceForRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/repos/owner/repo/issues", expected: ResourceCore},
+		{path: "/api/graphql", expected: ResourceGraphQL},
+		{path: "/search/issues", expected: ResourceSearch},
+		{path: "/api/v3/search/code", expected: ResourceCodeSearch},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			assert.Equal(t, tc.expected, resourceForRequest(req))
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Package repopolicy restricts the repositories tools may act on to allow- and deny-lists of
+// owner/repo glob patterns, so that an agent cannot read or change repositories it was not
+// meant to touch.
+package repopolicy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Access is the kind of access a call needs to a repository.
+type Access string
+
+const (
+	AccessRead  Access = "read"
+	AccessWrite Access = "write"
+)
+
+// ReasonPolicy is the reason reported in the structured content of refused tool calls.
+const ReasonPolicy = "repository_policy"
+
+// rule is a parsed allow- or deny-list entry.
+type rule struct {
+	// entry is the entry as given, for messages
+	entry string
+	// owner and repo are the lowercased glob patterns for each part of the name
+	owner, repo string
+	// access limits the rule to one kind of access; empty applies it to both
+	access Access
+}
+
+func (r rule) appliesTo(access Access) bool {
+	return r.access == "" || r.access == access
+}
+
+func (r rule) matchesOwner(owner string) bool {
+	matched, _ := path.Match(r.owner, strings.ToLower(owner))
+	return matched
+}
+
+func (r rule) matches(owner, repo string) bool {
+	matched, _ := path.Match(r.repo, strings.ToLower(repo))
+	return matched && r.matchesOwner(owner)
+}
+
+// Policy decides which repositories calls may read and write. A repository is allowed if
+// it matches an entry of every allow-list that applies to the access, and no entry of the
+// deny-lists that apply. A nil Policy allows everything.
+type Policy struct {
+	allow []rule
+	deny  []rule
+}
+
+// Parse builds a Policy from allow- and deny-list entries. Each entry is an owner/repo glob
+// pattern such as "my-org/*" or "my-org/api-*", where * does not match "/", optionally
+// prefixed with "read:" or "write:" to apply only to that kind of access. Matching is
+// case-insensitive, like GitHub names. It returns nil if there are no entries.
+func Parse(allow, deny []string) (*Policy, error) {
+	p := &Policy{}
+	for _, list := range []struct {
+		name    string
+		entries []string
+		rules   *[]rule
+	}{
+		{"allow", allow, &p.allow},
+		{"deny", deny, &p.deny},
+	} {
+		for _, entry := range list.entries {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			r, err := parseRule(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s-list entry %q: %w", list.name, entry, err)
+			}
+			*list.rules = append(*list.rules, r)
+		}
+	}
+	if len(p.allow) == 0 && len(p.deny) == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func parseRule(entry string) (rule, error) {
+	r := rule{entry: entry}
+	pattern := strings.ToLower(entry)
+	if access, rest, ok := strings.Cut(pattern, ":"); ok {
+		switch Access(access) {
+		case AccessRead, AccessWrite:
+			r.access = Access(access)
+			pattern = rest
+		default:
+			return rule{}, fmt.Errorf("unknown access %q: expected read or write", access)
+		}
+	}
+	owner, repo, ok := strings.Cut(pattern, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return rule{}, fmt.Errorf("expected owner/repo")
+	}
+	for _, part := range []string{owner, repo} {
+		if _, err := path.Match(part, ""); err != nil {
+			return rule{}, err
+		}
+	}
+	r.owner, r.repo = owner, repo
+	return r, nil
+}
+
+// String lists the entries of the policy.
+func (p *Policy) String() string {
+	if p == nil {
+		return "none"
+	}
+	entries := func(rules []rule) string {
+		parts := make([]string, len(rules))
+		for i, r := range rules {
+			parts[i] = r.entry
+		}
+		return strings.Join(parts, ", ")
+	}
+	switch {
+	case len(p.deny) == 0:
+		return "allow " + entries(p.allow)
+	case len(p.allow) == 0:
+		return "deny " + entries(p.deny)
+	default:
+		return "allow " + entries(p.allow) + "; deny " + entries(p.deny)
+	}
+}
+
+// allowLists returns the allow rules that apply to access, grouped into the rules for both
+// kinds of access and the rules for access alone. Empty groups are left out.
+func (p *Policy) allowLists(access Access) [][]rule {
+	var both, only []rule
+	for _, r := range p.allow {
+		switch r.access {
+		case "":
+			both = append(both, r)
+		case access:
+			only = append(only, r)
+		}
+	}
+	var lists [][]rule
+	for _, list := range [][]rule{both, only} {
+		if len(list) > 0 {
+			lists = append(lists, list)
+		}
+	}
+	return lists
+}
+
+// CheckRepo returns an *Error if the policy does not allow access to owner/repo.
+func (p *Policy) CheckRepo(owner, repo string, access Access) error {
+	if p == nil {
+		return nil
+	}
+	name := owner + "/" + repo
+	for _, r := range p.deny {
+		if r.appliesTo(access) && r.matches(owner, repo) {
+			return newError(name, access, r.entry, fmt.Sprintf("the repository policy denies %s access to %s (deny-list entry %q)", access, name, r.entry))
+		}
+	}
+	for _, list := range p.allowLists(access) {
+		if !anyRule(list, func(r rule) bool { return r.matches(owner, repo) }) {
+			return newError(name, access, "", fmt.Sprintf("the repository policy does not allow %s access to %s: it matches no allow-list entry", access, name))
+		}
+	}
+	return nil
+}
+
+// CheckOwner returns an *Error unless the policy allows access to every repository of owner,
+// as calls that act across an owner's repositories, such as organization-wide searches, may
+// reach any of them.
+func (p *Policy) CheckOwner(owner string, access Access) error {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.deny {
+		if r.appliesTo(access) && r.matchesOwner(owner) {
+			return newError(owner, access, r.entry, fmt.Sprintf("the repository policy denies %s access to some repositories of %s (deny-list entry %q), so calls across all its repositories are refused; name a single repository instead", access, owner, r.entry))
+		}
+	}
+	for _, list := range p.allowLists(access) {
+		if !anyRule(list, func(r rule) bool { return r.repo == "*" && r.matchesOwner(owner) }) {
+			return newError(owner, access, "", fmt.Sprintf("the repository policy does not allow %s access to all repositories of %s, so calls across all its repositories are refused; name an allowed repository instead", access, owner))
+		}
+	}
+	return nil
+}
+
+// Restricts reports whether the policy limits access to any repository, that is whether any
+// allow- or deny-list rule applies to access.
+func (p *Policy) Restricts(access Access) bool {
+	if p == nil {
+		return false
+	}
+	return len(p.allowLists(access)) > 0 || anyRule(p.deny, func(r rule) bool { return r.appliesTo(access) })
+}
+
+// SearchQualifiers returns the repo: and org: qualifiers that limit a search to the
+// repositories the policy allows for access. It returns no qualifiers and true if the policy
+// does not restrict access, and false if the allowed repositories cannot be written as
+// qualifiers, because there are deny rules but no allow rules, allow rules for both kinds
+// of access and for access alone, or wildcards other than a whole repository name.
+func (p *Policy) SearchQualifiers(access Access) ([]string, bool) {
+	if p == nil {
+		return nil, true
+	}
+	lists := p.allowLists(access)
+	if len(lists) == 0 {
+		return nil, !anyRule(p.deny, func(r rule) bool { return r.appliesTo(access) })
+	}
+	if len(lists) > 1 {
+		return nil, false
+	}
+	qualifiers := make([]string, 0, len(lists[0]))
+	for _, r := range lists[0] {
+		switch {
+		case isGlob(r.owner):
+			return nil, false
+		case r.repo == "*":
+			qualifiers = append(qualifiers, "org:"+r.owner)
+		case isGlob(r.repo):
+			return nil, false
+		default:
+			qualifiers = append(qualifiers, "repo:"+r.owner+"/"+r.repo)
+		}
+	}
+	return qualifiers, true
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+func anyRule(rules []rule, f func(rule) bool) bool {
+	for _, r := range rules {
+		if f(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Error is a refusal by the policy. It is reported in the structured content of the result
+// of a refused tool call.
+type Error struct {
+	Reason string `json:"reason"`
+	// Repository is the owner/repo, or the owner for calls across an owner's repositories
+	Repository string `json:"repository"`
+	Access     Access `json:"access"`
+	// Entry is the deny-list entry that matched, if any
+	Entry   string `json:"entry,omitempty"`
+	Message string `json:"message"`
+}
+
+func newError(repository string, access Access, entry, message string) *Error {
+	return &Error{Reason: ReasonPolicy, Repository: repository, Access: access, Entry: entry, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Result returns the error result of a tool call refused by the policy.
+func (e *Error) Result(tool string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s was not run: %s", tool, e.Message)}},
+		StructuredContent: e,
+		IsError:           true,
+	}
+}
@@ -0,0 +1,158 @@
+package repopolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		allow, deny []string
+		expectedErr string
+		expectNil   bool
+	}{
+		{name: "no entries", expectNil: true},
+		{name: "blank entries", allow: []string{" "}, expectNil: true},
+		{name: "patterns and access prefixes", allow: []string{"my-org/*", "write:my-org/sandbox-*"}, deny: []string{"read:*/secrets"}},
+		{name: "missing repo", allow: []string{"my-org"}, expectedErr: `invalid allow-list entry "my-org": expected owner/repo`},
+		{name: "too many parts", deny: []string{"my-org/repo/extra"}, expectedErr: "expected owner/repo"},
+		{name: "unknown access", allow: []string{"admin:my-org/*"}, expectedErr: `unknown access "admin"`},
+		{name: "malformed pattern", allow: []string{"my-org/[repo"}, expectedErr: "syntax error in pattern"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := Parse(tc.allow, tc.deny)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectNil, policy == nil)
+		})
+	}
+}
+
+func TestCheckRepo(t *testing.T) {
+	t.Parallel()
+
+	policy, err := Parse(
+		[]string{"my-org/*", "octocat/hello-world", "write:my-org/sandbox-*"},
+		[]string{"my-org/secrets", "read:my-org/private-*"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		owner, repo string
+		access      Access
+		allowed     bool
+	}{
+		{"my-org", "api", AccessRead, true},
+		{"My-Org", "API", AccessRead, true},
+		{"octocat", "hello-world", AccessRead, true},
+		{"other", "repo", AccessRead, false},
+		{"my-org", "secrets", AccessRead, false},
+		{"my-org", "secrets", AccessWrite, false},
+		{"my-org", "private-notes", AccessRead, false},
+		{"my-org", "sandbox-1", AccessWrite, true},
+		{"my-org", "api", AccessWrite, false},
+		{"octocat", "hello-world", AccessWrite, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.owner+"/"+tc.repo+"/"+string(tc.access), func(t *testing.T) {
+			t.Parallel()
+			err := policy.CheckRepo(tc.owner, tc.repo, tc.access)
+			if tc.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *Error
+			require.True(t, errors.As(err, &policyErr))
+			assert.Equal(t, ReasonPolicy, policyErr.Reason)
+			assert.Equal(t, tc.owner+"/"+tc.repo, policyErr.Repository)
+			assert.Equal(t, tc.access, policyErr.Access)
+		})
+	}
+
+	err = policy.CheckRepo("my-org", "secrets", AccessRead)
+	assert.EqualError(t, err, `the repository policy denies read access to my-org/secrets (deny-list entry "my-org/secrets")`)
+}
+
+func TestCheckOwner(t *testing.T) {
+	t.Parallel()
+
+	policy, err := Parse([]string{"my-org/*", "octocat/hello-world"}, []string{"write:my-org/prod"})
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.CheckOwner("my-org", AccessRead))
+	assert.Error(t, policy.CheckOwner("my-org", AccessWrite), "a denied repository makes the owner partly denied")
+	assert.Error(t, policy.CheckOwner("octocat", AccessRead), "a single allowed repository does not allow the owner")
+	assert.Error(t, policy.CheckOwner("other", AccessRead))
+
+	var nilPolicy *Policy
+	assert.NoError(t, nilPolicy.CheckOwner("anyone", AccessWrite))
+	assert.NoError(t, nilPolicy.CheckRepo("anyone", "anything", AccessWrite))
+}
+
+func TestRestricts(t *testing.T) {
+	t.Parallel()
+
+	policy, err := Parse([]string{"write:my-org/*"}, nil)
+	require.NoError(t, err)
+	assert.True(t, policy.Restricts(AccessWrite))
+	assert.False(t, policy.Restricts(AccessRead))
+
+	policy, err = Parse(nil, []string{"my-org/secrets"})
+	require.NoError(t, err)
+	assert.True(t, policy.Restricts(AccessRead))
+
+	var nilPolicy *Policy
+	assert.False(t, nilPolicy.Restricts(AccessWrite))
+}
+
+func TestSearchQualifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		allow, deny []string
+		qualifiers  []string
+		ok          bool
+	}{
+		{name: "literal repositories and owners", allow: []string{"my-org/*", "octocat/hello-world"}, qualifiers: []string{"org:my-org", "repo:octocat/hello-world"}, ok: true},
+		{name: "write rules do not limit reads", allow: []string{"write:my-org/*"}, ok: true},
+		{name: "read rules", allow: []string{"read:my-org/*"}, qualifiers: []string{"org:my-org"}, ok: true},
+		{name: "deny rules only", deny: []string{"my-org/secrets"}, ok: false},
+		{name: "repository wildcard", allow: []string{"my-org/api-*"}, ok: false},
+		{name: "owner wildcard", allow: []string{"*/docs"}, ok: false},
+		{name: "rules for both and read alone", allow: []string{"my-org/*", "read:octocat/*"}, ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := Parse(tc.allow, tc.deny)
+			require.NoError(t, err)
+			qualifiers, ok := policy.SearchQualifiers(AccessRead)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.qualifiers, qualifiers)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	policy, err := Parse([]string{"my-org/*", "write:my-org/sandbox"}, []string{"my-org/secrets"})
+	require.NoError(t, err)
+	assert.Equal(t, "allow my-org/*, write:my-org/sandbox; deny my-org/secrets", policy.String())
+}
@@ -0,0 +1,242 @@
+package tokensource
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v79/github"
+)
+
+const (
+	// appJWTLifetime is how long a minted App JWT is valid for. GitHub allows at most 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew backdates the JWT issue time to allow for clock drift between us and GitHub.
+	appJWTClockSkew = 60 * time.Second
+
+	// installationTokenRefreshMargin is how long before expiry an installation token is refreshed,
+	// so that in-flight requests never carry an expired token.
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// AppOptions configures authentication as a GitHub App installation.
+type AppOptions struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64
+
+	// PrivateKey is the PEM encoded private key of the GitHub App.
+	PrivateKey []byte
+
+	// InstallationID is the ID of the installation to authenticate as.
+	// If zero, the installation is looked up from Owner.
+	InstallationID int64
+
+	// Owner is the organization or user account the App is installed on.
+	// Only used when InstallationID is zero.
+	Owner string
+
+	// BaseURL is the REST API URL of the GitHub host (e.g. https://api.github.com/).
+	// Defaults to https://api.github.com/ if nil.
+	BaseURL *url.URL
+
+	// HTTPClient is used for the App API requests. http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+
+	// UserAgent is sent with the App API requests. The go-github user agent is used if empty.
+	UserAgent string
+}
+
+// AppInstallation is a TokenSource that authenticates as a GitHub App installation.
+// It mints a JWT signed with the App private key, exchanges it for an installation
+// token, and refreshes the installation token shortly before it expires.
+type AppInstallation struct {
+	appID      int64
+	key        *rsa.PrivateKey
+	owner      string
+	baseURL    *url.URL
+	httpClient *http.Client
+	userAgent  string
+
+	// now is stubbed in tests
+	now func() time.Time
+
+	mu             sync.Mutex
+	installationID int64
+	token          string
+	expiresAt      time.Time
+}
+
+// NewAppInstallation creates a TokenSource for a GitHub App installation.
+// No request is made until the first token is needed.
+func NewAppInstallation(opts AppOptions) (*AppInstallation, error) {
+	if opts.AppID == 0 {
+		return nil, errors.New("app ID is required")
+	}
+	if opts.InstallationID == 0 && opts.Owner == "" {
+		return nil, errors.New("either an installation ID or an installation owner is required")
+	}
+
+	key, err := ParsePrivateKey(opts.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &AppInstallation{
+		appID:          opts.AppID,
+		key:            key,
+		owner:          opts.Owner,
+		baseURL:        opts.BaseURL,
+		httpClient:     httpClient,
+		userAgent:      opts.UserAgent,
+		now:            time.Now,
+		installationID: opts.InstallationID,
+	}, nil
+}
+
+// Token returns a valid installation token, exchanging a new App JWT for one if the
+// cached token is missing or about to expire.
+func (a *AppInstallation) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Before(a.expiresAt.Add(-installationTokenRefreshMargin)) {
+		return a.token, nil
+	}
+
+	client, err := a.appClient()
+	if err != nil {
+		return "", err
+	}
+
+	if a.installationID == 0 {
+		id, err := findInstallationID(ctx, client, a.owner)
+		if err != nil {
+			return "", err
+		}
+		a.installationID = id
+	}
+
+	installationToken, _, err := client.Apps.CreateInstallationToken(ctx, a.installationID, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create installation token for installation %d: %w", a.installationID, err)
+	}
+
+	a.token = installationToken.GetToken()
+	a.expiresAt = installationToken.GetExpiresAt().Time
+	return a.token, nil
+}
+
+// Invalidate discards the installation token if it is still the cached one, for example
+// after the installation was suspended and reinstated, so that the next call to Token
+// exchanges a new JWT for a new token.
+func (a *AppInstallation) Invalidate(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == token {
+		a.token = ""
+	}
+}
+
+// appClient returns a REST client authenticated as the App itself.
+func (a *AppInstallation) appClient() (*gogithub.Client, error) {
+	jwt, err := a.signJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	client := gogithub.NewClient(a.httpClient).WithAuthToken(jwt)
+	if a.baseURL != nil {
+		client.BaseURL = a.baseURL
+	}
+	if a.userAgent != "" {
+		client.UserAgent = a.userAgent
+	}
+	return client, nil
+}
+
+// signJWT mints an RS256 JWT identifying the App, as described in
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/generating-a-json-web-token-jwt-for-a-github-app
+func (a *AppInstallation) signJWT() (string, error) {
+	now := a.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// findInstallationID looks up the installation of the App on an organization or user account.
+func findInstallationID(ctx context.Context, client *gogithub.Client, owner string) (int64, error) {
+	installation, resp, err := client.Apps.FindOrganizationInstallation(ctx, owner)
+	if err == nil {
+		return installation.GetID(), nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return 0, fmt.Errorf("failed to find installation for %s: %w", owner, err)
+	}
+
+	installation, _, err = client.Apps.FindUserInstallation(ctx, owner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find installation for %s: %w", owner, err)
+	}
+	return installation.GetID(), nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA private key in PKCS#1 or PKCS#8 form,
+// as downloaded from the GitHub App settings page.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode app private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse app private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("failed to parse app private key: not an RSA key")
+	}
+	return key, nil
+}
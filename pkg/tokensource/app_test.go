@@ -0,0 +1,150 @@
+package tokensource
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, pemBytes
+}
+
+// verifyJWT checks the signature of an App JWT and returns its claims.
+func verifyJWT(t *testing.T, key *rsa.PrivateKey, jwt string) map[string]any {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+func TestAppInstallation_Token(t *testing.T) {
+	t.Parallel()
+
+	key, pemBytes := generateKey(t)
+	expiresAt := time.Now().Add(time.Hour)
+
+	var tokenRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/octo-org/installation", func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		assert.Equal(t, "42", claims["iss"])
+		_, _ = fmt.Fprint(w, `{"id": 7}`)
+	})
+	mux.HandleFunc("POST /app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		verifyJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		assert.Equal(t, "github-mcp-server/test", r.Header.Get("User-Agent"))
+		n := tokenRequests.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token": "ghs_token%d", "expires_at": %q}`, n, expiresAt.Format(time.RFC3339))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	baseURL, err := url.Parse(ts.URL + "/")
+	require.NoError(t, err)
+
+	source, err := NewAppInstallation(AppOptions{
+		AppID:      42,
+		PrivateKey: pemBytes,
+		Owner:      "octo-org",
+		BaseURL:    baseURL,
+		UserAgent:  "github-mcp-server/test",
+	})
+	require.NoError(t, err)
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_token1", token)
+
+	// The cached token is reused while it is valid
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_token1", token)
+	assert.Equal(t, int32(1), tokenRequests.Load())
+
+	// Close to expiry a new token is fetched
+	source.now = func() time.Time { return expiresAt.Add(-time.Minute) }
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_token2", token)
+}
+
+func TestNewAppInstallation_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, pemBytes := generateKey(t)
+
+	tests := []struct {
+		name          string
+		opts          AppOptions
+		expectedError string
+	}{
+		{
+			name:          "missing app ID",
+			opts:          AppOptions{PrivateKey: pemBytes, InstallationID: 1},
+			expectedError: "app ID is required",
+		},
+		{
+			name:          "missing installation",
+			opts:          AppOptions{AppID: 1, PrivateKey: pemBytes},
+			expectedError: "installation ID or an installation owner is required",
+		},
+		{
+			name:          "invalid key",
+			opts:          AppOptions{AppID: 1, PrivateKey: []byte("not a key"), InstallationID: 1},
+			expectedError: "no PEM data found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewAppInstallation(tc.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+func TestParsePrivateKey_PKCS8(t *testing.T) {
+	t.Parallel()
+
+	key, _ := generateKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	parsed, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+}
@@ -0,0 +1,221 @@
+package tokensource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minRetryDelay is the wait before reading the token again after a failed read. It
+	// doubles with every failure in a row, up to maxRetryDelay.
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute
+
+	// defaultFetchTimeout bounds a single read of the token, so that a hung credential helper
+	// fails like any other failed read.
+	defaultFetchTimeout = 30 * time.Second
+)
+
+// FetchFunc reads the current token from wherever it is stored.
+type FetchFunc func(ctx context.Context) (string, error)
+
+// Invalidator is implemented by token sources that can discard a token the GitHub API
+// has rejected, so that the next call to Token fetches a fresh one.
+type Invalidator interface {
+	Invalidate(token string)
+}
+
+// Refreshing is a TokenSource that caches a token read by a FetchFunc and reads it again
+// once the refresh interval has passed or the token has been invalidated. This picks up
+// rotated credentials without restarting the server. Failed reads are retried with a
+// backoff, so that a broken credential helper is not run on every request.
+//
+// Concurrent calls share a single read, which runs with a timeout of its own, and each
+// caller stops waiting for it when its context is done.
+type Refreshing struct {
+	fetch    FetchFunc
+	interval time.Duration
+
+	// now and timeout are stubbed in tests
+	now     func() time.Time
+	timeout time.Duration
+
+	mu        sync.Mutex
+	token     string
+	fetchedAt time.Time
+	stale     bool
+	onRotate  []func(token string)
+
+	// inflight is the read in progress, if any
+	inflight *fetchCall
+
+	// failures counts the failed reads in a row; until retryAt, Token returns the previous
+	// token or, if there is none, err without reading again
+	failures int
+	retryAt  time.Time
+	err      error
+}
+
+// NewRefreshing creates a Refreshing token source. An interval of zero means the token is
+// only read again after it has been invalidated.
+func NewRefreshing(fetch FetchFunc, interval time.Duration) *Refreshing {
+	return &Refreshing{
+		fetch:    fetch,
+		interval: interval,
+		now:      time.Now,
+		timeout:  defaultFetchTimeout,
+	}
+}
+
+// fetchCall is a read of the token shared by the callers of Token waiting for it. token and
+// err are set before done is closed.
+type fetchCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// File returns a token source that reads the token from a file, such as a mounted secret.
+func File(path string, interval time.Duration) *Refreshing {
+	return NewRefreshing(func(_ context.Context) (string, error) {
+		data, err := os.ReadFile(path) //nolint:gosec // the path is configured by the operator
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		return trimToken(string(data))
+	}, interval)
+}
+
+// Command returns a token source that runs a credential helper, such as `gh auth token`,
+// and uses its standard output as the token.
+func Command(name string, args []string, interval time.Duration) *Refreshing {
+	return NewRefreshing(func(ctx context.Context) (string, error) {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to run token command %q: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+		}
+		return trimToken(string(out))
+	}, interval)
+}
+
+// Token returns the cached token, reading it again if it is missing or stale.
+func (r *Refreshing) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	if r.token != "" && !r.stale && (r.interval == 0 || r.now().Before(r.fetchedAt.Add(r.interval))) {
+		defer r.mu.Unlock()
+		return r.token, nil
+	}
+
+	if r.now().Before(r.retryAt) {
+		defer r.mu.Unlock()
+		if r.token != "" {
+			return r.token, nil
+		}
+		return "", r.err
+	}
+
+	call := r.inflight
+	if call == nil {
+		call = &fetchCall{done: make(chan struct{})}
+		r.inflight = call
+		// The read is shared, so it must not end when the caller that started it gives up
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+		go func() {
+			defer cancel()
+			r.runFetch(fetchCtx, call)
+		}()
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// runFetch reads the token for call and records the result.
+func (r *Refreshing) runFetch(ctx context.Context, call *fetchCall) {
+	token, err := r.fetch(ctx)
+
+	r.mu.Lock()
+	defer func() {
+		r.inflight = nil
+		r.mu.Unlock()
+		close(call.done)
+	}()
+
+	if err != nil {
+		r.failures++
+		r.retryAt = r.now().Add(retryDelay(r.failures))
+		r.err = err
+		// Keep serving the previous token if there is one, as a failed read of a rotated
+		// credential should not take the server down.
+		call.token, call.err = r.token, nil
+		if r.token == "" {
+			call.err = err
+		}
+		return
+	}
+
+	previous := r.token
+	r.token = token
+	r.fetchedAt = r.now()
+	r.stale = false
+	r.failures = 0
+	r.retryAt = time.Time{}
+	r.err = nil
+	if previous != "" && previous != token {
+		for _, fn := range r.onRotate {
+			go fn(token)
+		}
+	}
+	call.token = token
+}
+
+// Invalidate discards the token if it is still the cached one, so that the next call to
+// Token reads it again.
+func (r *Refreshing) Invalidate(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.token == token {
+		r.stale = true
+	}
+}
+
+// OnRotate registers a function that is called in a new goroutine whenever a refresh
+// returns a different token than before.
+func (r *Refreshing) OnRotate(fn func(token string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onRotate = append(r.onRotate, fn)
+}
+
+// retryDelay returns the wait before reading the token again after failures failed reads.
+func retryDelay(failures int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+func trimToken(s string) (string, error) {
+	token := strings.TrimSpace(s)
+	if token == "" {
+		return "", errors.New("token is empty")
+	}
+	return token, nil
+}
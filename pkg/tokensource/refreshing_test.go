@@ -0,0 +1,277 @@
+package tokensource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshing_Interval(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	source := NewRefreshing(func(_ context.Context) (string, error) {
+		n := calls.Add(1)
+		return []string{"first", "second"}[n-1], nil
+	}, time.Minute)
+
+	now := time.Now()
+	source.now = func() time.Time { return now }
+
+	rotated := make(chan string, 1)
+	source.OnRotate(func(token string) { rotated <- token })
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", token)
+
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", token)
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(2 * time.Minute)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	select {
+	case token := <-rotated:
+		assert.Equal(t, "second", token)
+	case <-time.After(time.Second):
+		t.Fatal("expected rotation callback")
+	}
+}
+
+func TestRefreshing_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	source := NewRefreshing(func(_ context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			return "old", nil
+		}
+		return "new", nil
+	}, 0)
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "old", token)
+
+	// Invalidating a token that is no longer cached is a no-op
+	source.Invalidate("something-else")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "old", token)
+
+	source.Invalidate("old")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "new", token)
+}
+
+func TestRefreshing_FetchError(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+	source := NewRefreshing(func(_ context.Context) (string, error) {
+		if fail.Load() {
+			return "", errors.New("helper failed")
+		}
+		return "token", nil
+	}, 0)
+
+	now := time.Now()
+	source.now = func() time.Time { return now }
+
+	fail.Store(true)
+	_, err := source.Token(context.Background())
+	require.ErrorContains(t, err, "helper failed")
+
+	fail.Store(false)
+	now = now.Add(minRetryDelay)
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	// A failed refresh keeps serving the previous token
+	fail.Store(true)
+	source.Invalidate("token")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
+
+func TestRefreshing_FetchErrorBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var fail atomic.Bool
+	source := NewRefreshing(func(_ context.Context) (string, error) {
+		calls.Add(1)
+		if fail.Load() {
+			return "", errors.New("helper failed")
+		}
+		return "token", nil
+	}, 0)
+
+	now := time.Now()
+	source.now = func() time.Time { return now }
+
+	// Without a token, the error is returned until the retry is due
+	fail.Store(true)
+	for range 3 {
+		_, err := source.Token(context.Background())
+		require.ErrorContains(t, err, "helper failed")
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// The wait doubles with every failure in a row
+	now = now.Add(minRetryDelay)
+	_, err := source.Token(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	now = now.Add(minRetryDelay)
+	_, err = source.Token(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	now = now.Add(minRetryDelay)
+
+	fail.Store(false)
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// With a token, an invalidated token is served again until the retry is due
+	fail.Store(true)
+	source.Invalidate("token")
+	for range 3 {
+		token, err = source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token", token)
+	}
+	assert.Equal(t, int32(4), calls.Load())
+
+	fail.Store(false)
+	now = now.Add(minRetryDelay)
+	_, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestRefreshing_HungFetch(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+	source := NewRefreshing(func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		// A hung helper only returns when its context ends
+		<-ctx.Done()
+		return "", ctx.Err()
+	}, 0)
+	source.timeout = 100 * time.Millisecond
+
+	// A caller whose context ends stops waiting, without ending the read for others
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := source.Token(ctx)
+		errs <- err
+	}()
+	<-started
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+
+	// Other callers share the read, which fails once it times out
+	_, err := source.Token(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, minRetryDelay, retryDelay(1))
+	assert.Equal(t, 2*minRetryDelay, retryDelay(2))
+	assert.Equal(t, 4*minRetryDelay, retryDelay(3))
+	assert.Equal(t, maxRetryDelay, retryDelay(100))
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("ghp_first\n"), 0o600))
+
+	source := File(path, 0)
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_first", token)
+
+	require.NoError(t, os.WriteFile(path, []byte("ghp_second\n"), 0o600))
+	source.Invalidate("ghp_first")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_second", token)
+
+	_, err = File(filepath.Join(t.TempDir(), "missing"), 0).Token(context.Background())
+	require.ErrorContains(t, err, "failed to read token file")
+}
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	token, err := Command("echo", []string{"ghp_from_helper"}, 0).Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_from_helper", token)
+
+	_, err = Command("false", nil, 0).Token(context.Background())
+	require.ErrorContains(t, err, "failed to run token command")
+}
+
+func TestTransport_RetriesWithRefreshedTokenOn401(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer ts.Close()
+
+	var calls atomic.Int32
+	source := NewRefreshing(func(_ context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			return "old", nil
+		}
+		return "new", nil
+	}, 0)
+
+	client := &http.Client{Transport: &Transport{Source: source}}
+	resp, err := client.Post(ts.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(body))
+}
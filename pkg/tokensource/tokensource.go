@@ -0,0 +1,89 @@
+// Package tokensource provides the tokens used to authenticate requests to the GitHub API
+package tokensource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TokenSource returns the token to authenticate the next GitHub API request with.
+// Implementations must be safe for concurrent use, and may refresh the token between calls.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// Static returns a TokenSource that always returns the same token.
+func Static(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// Transport is an http.RoundTripper that authenticates every request with a bearer token
+// from a TokenSource. A single Transport can be shared by the REST, GraphQL and raw clients
+// so that they all pick up a refreshed token at the same time.
+type Transport struct {
+	// Source provides the token for each request.
+	Source TokenSource
+
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper. If the GitHub API rejects the token with
+// 401 Unauthorized and the source is an Invalidator, the token is invalidated and the
+// request is retried once with a fresh token.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	resp, err := t.roundTripWithToken(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	invalidator, ok := t.Source.(Invalidator)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	invalidator.Invalidate(token)
+
+	newToken, err := t.Source.Token(req.Context())
+	if err != nil || newToken == token {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return t.roundTripWithToken(retry, newToken)
+}
+
+func (t *Transport) roundTripWithToken(req *http.Request, token string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base().RoundTrip(req)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
@@ -0,0 +1,46 @@
+package tokensource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token(_ context.Context) (string, error) {
+	return "", errors.New("token unavailable")
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+	}))
+	defer ts.Close()
+
+	t.Run("sets bearer token", func(t *testing.T) {
+		client := &http.Client{Transport: &Transport{Source: Static("abc")}}
+		resp, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer abc", string(body))
+	})
+
+	t.Run("token source error fails request", func(t *testing.T) {
+		client := &http.Client{Transport: &Transport{Source: failingTokenSource{}}}
+		_, err := client.Get(ts.URL) //nolint:bodyclose // no response on error
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "token unavailable")
+	})
+}
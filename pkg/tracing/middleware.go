@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes for MCP requests, following the OpenTelemetry semantic conventions for MCP.
+const (
+	attrMCPMethod    = attribute.Key("mcp.method.name")
+	attrMCPSessionID = attribute.Key("mcp.session.id")
+	attrToolName     = attribute.Key("gen_ai.tool.name")
+)
+
+// Middleware returns receiving middleware for an mcp.Server that opens a span for every
+// MCP request, named after the method and, for tool calls, the tool. GitHub API requests
+// made while handling the request become child spans when their client uses Transport.
+// A nil provider uses the global tracer provider.
+func Middleware(provider trace.TracerProvider) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			// Continue the trace of the client when it is sent over HTTP
+			if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+				ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(http.Header(extra.Header)))
+			}
+
+			name := method
+			attrs := []attribute.KeyValue{attrMCPMethod.String(method)}
+			if callRequest, ok := req.(*mcp.CallToolRequest); ok && callRequest.Params != nil {
+				name = method + " " + callRequest.Params.Name
+				attrs = append(attrs, attrToolName.String(callRequest.Params.Name))
+			}
+			if session, ok := req.GetSession().(*mcp.ServerSession); ok && session != nil && session.ID() != "" {
+				attrs = append(attrs, attrMCPSessionID.String(session.ID()))
+			}
+
+			ctx, span := tracerFrom(provider).Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			result, err := next(ctx, method, req)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			case isToolError(result):
+				span.SetAttributes(semconv.ErrorTypeKey.String("tool_error"))
+				span.SetStatus(codes.Error, "tool returned an error")
+			}
+			return result, err
+		}
+	}
+}
+
+func isToolError(result mcp.Result) bool {
+	callResult, ok := result.(*mcp.CallToolResult)
+	return ok && callResult != nil && callResult.IsError
+}
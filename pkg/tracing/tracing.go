@@ -0,0 +1,68 @@
+// Package tracing records OpenTelemetry spans for MCP requests and the GitHub API requests
+// made while serving them, and exports them over OTLP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer of this server.
+const instrumentationName = "github.com/github/github-mcp-server"
+
+// serviceName is reported as the service.name resource attribute.
+const serviceName = "github-mcp-server"
+
+// Options configures the export of spans.
+type Options struct {
+	// Endpoint is the URL of the OTLP/HTTP collector, e.g. http://localhost:4318.
+	// The standard OTEL_EXPORTER_OTLP_* environment variables configure headers,
+	// certificates and timeouts.
+	Endpoint string
+
+	// ServiceVersion is reported as the service.version resource attribute
+	ServiceVersion string
+}
+
+// Setup installs a global tracer provider that exports spans to the OTLP endpoint.
+// The returned function flushes pending spans and must be called before exiting.
+// Until Setup is called, the global tracer provider records nothing.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// tracerFrom returns the tracer of this server from provider, or from the global provider if nil.
+func tracerFrom(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(instrumentationName)
+}
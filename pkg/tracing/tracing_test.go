@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	return recorder, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+}
+
+func attributeMap(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		method         string
+		req            mcp.Request
+		result         mcp.Result
+		err            error
+		expectedName   string
+		expectedTool   string
+		expectedStatus codes.Code
+	}{
+		{
+			name:   "tool call",
+			method: "tools/call",
+			req: &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+				Name: "get_me",
+			}},
+			result:         &mcp.CallToolResult{},
+			expectedName:   "tools/call get_me",
+			expectedTool:   "get_me",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:   "tool call returning an error result",
+			method: "tools/call",
+			req: &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+				Name: "get_me",
+			}},
+			result:         &mcp.CallToolResult{IsError: true},
+			expectedName:   "tools/call get_me",
+			expectedTool:   "get_me",
+			expectedStatus: codes.Error,
+		},
+		{
+			name:           "failed request",
+			method:         "tools/list",
+			req:            &mcp.ListToolsRequest{},
+			err:            errors.New("boom"),
+			expectedName:   "tools/list",
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder, provider := newRecorder()
+			handler := Middleware(provider)(func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				return tc.result, tc.err
+			})
+
+			_, err := handler(context.Background(), tc.method, tc.req)
+			assert.Equal(t, tc.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.expectedName, spans[0].Name())
+			assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+			attrs := attributeMap(spans[0])
+			assert.Equal(t, tc.method, attrs[attrMCPMethod].AsString())
+			assert.Equal(t, tc.expectedTool, attrs[attrToolName].AsString())
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.URL.Path == "/repos/owner/repo/issues/404" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	rawURL, err := url.Parse(ts.URL + "/raw/")
+	require.NoError(t, err)
+
+	recorder, provider := newRecorder()
+	client := &http.Client{Transport: &Transport{RawURL: rawURL, TracerProvider: provider}}
+
+	// Spans of API requests are children of the span in the request context
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	for _, path := range []string{"/repos/owner/repo/issues/1", "/repos/owner/repo/issues/404", "/graphql", "/raw/owner/repo/HEAD/README.md"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+
+	expected := []struct {
+		name       string
+		repository string
+		status     int64
+		code       codes.Code
+	}{
+		{name: "GitHub rest GET", repository: "owner/repo", status: 200, code: codes.Unset},
+		{name: "GitHub rest GET", repository: "owner/repo", status: 404, code: codes.Error},
+		{name: "GitHub graphql GET", status: 200, code: codes.Unset},
+		{name: "GitHub raw GET", repository: "owner/repo", status: 200, code: codes.Unset},
+	}
+	for i, exp := range expected {
+		span := spans[i]
+		assert.Equal(t, exp.name, span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, exp.code, span.Status().Code)
+
+		attrs := attributeMap(span)
+		assert.Equal(t, exp.repository, attrs[attrRepository].AsString())
+		assert.Equal(t, exp.status, attrs["http.response.status_code"].AsInt64())
+		assert.Equal(t, "ABCD:1234", attrs[attrRequestID].AsString())
+		assert.Equal(t, "core", attrs[attrRateLimitResource].AsString())
+		assert.Equal(t, int64(4999), attrs[attrRateLimitRemaining].AsInt64())
+	}
+}
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes for GitHub API requests.
+const (
+	attrAPI                = attribute.Key("github.api")
+	attrRepository         = attribute.Key("github.repository")
+	attrRequestID          = attribute.Key("github.request_id")
+	attrCacheHit           = attribute.Key("github.cache_hit")
+	attrRateLimitResource  = attribute.Key("github.rate_limit.resource")
+	attrRateLimitLimit     = attribute.Key("github.rate_limit.limit")
+	attrRateLimitRemaining = attribute.Key("github.rate_limit.remaining")
+	attrRateLimitUsed      = attribute.Key("github.rate_limit.used")
+	attrRateLimitReset     = attribute.Key("github.rate_limit.reset")
+)
+
+// Transport is an http.RoundTripper that records a client span for every GitHub API request,
+// with its status, rate limit headers and the repository it targets.
+type Transport struct {
+	// Base is the underlying transport. http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// RawURL is the base URL of the raw content API, to tell raw requests apart from REST requests.
+	RawURL *url.URL
+
+	// TracerProvider creates the spans. The global tracer provider is used if nil.
+	TracerProvider trace.TracerProvider
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	api := utils.APIFromURL(req.URL, t.RawURL)
+	attrs := []attribute.KeyValue{
+		attrAPI.String(api),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+		semconv.URLPath(req.URL.Path),
+	}
+	if owner, repo, ok := utils.RepoFromURL(req.URL, t.RawURL); ok {
+		attrs = append(attrs, attrRepository.String(owner+"/"+repo))
+	}
+
+	ctx, span := tracerFrom(t.TracerProvider).Start(req.Context(), "GitHub "+api+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	resp, err := t.base().RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	span.SetAttributes(responseAttributes(resp.Header)...)
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// responseAttributes records the request ID, cache use and rate limit headers of a GitHub API response.
+func responseAttributes(h http.Header) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if id := h.Get("X-GitHub-Request-Id"); id != "" {
+		attrs = append(attrs, attrRequestID.String(id))
+	}
+	if h.Get("X-From-Cache") != "" {
+		attrs = append(attrs, attrCacheHit.Bool(true))
+	}
+	if resource := h.Get("X-RateLimit-Resource"); resource != "" {
+		attrs = append(attrs, attrRateLimitResource.String(resource))
+	}
+	for key, header := range map[attribute.Key]string{
+		attrRateLimitLimit:     "X-RateLimit-Limit",
+		attrRateLimitRemaining: "X-RateLimit-Remaining",
+		attrRateLimitUsed:      "X-RateLimit-Used",
+		attrRateLimitReset:     "X-RateLimit-Reset",
+	} {
+		if value, err := strconv.ParseInt(h.Get(header), 10, 64); err == nil {
+			attrs = append(attrs, key.Int64(value))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,50 @@
+package utils //nolint:revive //TODO: figure out a better name for this package
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RepoFromURL returns the repository a GitHub API request URL belongs to, for REST URLs
+// containing /repos/{owner}/{repo} and for URLs under rawURL, the base URL of the raw
+// content API, if given. ok is false for URLs that are not repository scoped.
+func RepoFromURL(u *url.URL, rawURL *url.URL) (owner, repo string, ok bool) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+2 < len(segments); i++ {
+		if segments[i] == "repos" {
+			return segments[i+1], segments[i+2], true
+		}
+	}
+
+	if rawURL != nil && u.Host == rawURL.Host {
+		rest, found := strings.CutPrefix(strings.Trim(u.Path, "/"), strings.Trim(rawURL.Path, "/"))
+		if !found {
+			return "", "", false
+		}
+		segments := strings.Split(strings.Trim(rest, "/"), "/")
+		if len(segments) >= 2 && segments[0] != "" {
+			return segments[0], segments[1], true
+		}
+	}
+	return "", "", false
+}
+
+// The GitHub APIs a request can target, as returned by APIFromURL.
+const (
+	APIREST    = "rest"
+	APIGraphQL = "graphql"
+	APIRaw     = "raw"
+)
+
+// APIFromURL returns which GitHub API a request URL targets, given rawURL, the base URL of
+// the raw content API, to tell raw requests apart from REST requests.
+func APIFromURL(u *url.URL, rawURL *url.URL) string {
+	switch {
+	case strings.HasSuffix(u.Path, "/graphql"):
+		return APIGraphQL
+	case rawURL != nil && u.Host == rawURL.Host && strings.HasPrefix(u.Path, rawURL.Path):
+		return APIRaw
+	default:
+		return APIREST
+	}
+}